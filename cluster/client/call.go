@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/packet"
+)
+
+type pendingCall struct {
+	route int32                // 路由号
+	reply chan *packet.Message // 回复消息
+}
+
+// Call 发送请求消息并等待网关回复，回复消息通过客户端的编解码器与加密器解析到reply中
+// 请求会自动分配消息序列号，ctx未设置超时时间时使用客户端的RPC调用超时时间
+// 未匹配到等待中请求的消息仍交由路由处理器进行处理
+func (c *Conn) Call(ctx context.Context, route int32, req any, reply any) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.client.opts.timeout)
+		defer cancel()
+	}
+
+	call := &pendingCall{route: route, reply: make(chan *packet.Message, 1)}
+
+	seq, err := c.doCall(route, req, call)
+	if err != nil {
+		return err
+	}
+	defer c.calls.Delete(seq)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case message, ok := <-call.reply:
+		if !ok {
+			return errors.ErrConnectionClosed
+		}
+
+		if reply == nil {
+			return nil
+		}
+
+		return (&Context{ctx: ctx, conn: c, message: message}).Parse(reply)
+	}
+}
+
+// 分配序列号并发送请求消息
+func (c *Conn) doCall(route int32, req any, call *pendingCall) (int32, error) {
+	for retry := true; ; retry = false {
+		seq := c.doGenSequence()
+
+		c.calls.Store(seq, call)
+
+		err := c.Push(&cluster.Message{Seq: seq, Route: route, Data: req})
+		if err == nil {
+			return seq, nil
+		}
+
+		c.calls.Delete(seq)
+
+		// 序列号超出打包器的序列号字节数时重新开始分配
+		if retry && errors.Is(err, errors.ErrSeqOverflow) {
+			c.seq.Store(0)
+			continue
+		}
+
+		return 0, err
+	}
+}
+
+// 生成请求序列号
+func (c *Conn) doGenSequence() int32 {
+	for {
+		if seq := c.seq.Add(1); seq > 0 {
+			return seq
+		}
+
+		c.seq.Store(0)
+	}
+}
+
+// 匹配等待中的请求，匹配成功返回true
+func (c *Conn) resolve(message *packet.Message) bool {
+	if message.Seq == 0 {
+		return false
+	}
+
+	val, ok := c.calls.Load(message.Seq)
+	if !ok || val.(*pendingCall).route != message.Route {
+		return false
+	}
+
+	if _, ok = c.calls.LoadAndDelete(message.Seq); !ok {
+		return false
+	}
+
+	val.(*pendingCall).reply <- message
+
+	return true
+}
+
+// 取消所有等待中的请求
+func (c *Conn) cancelCalls() {
+	c.calls.Range(func(seq, val any) bool {
+		if _, ok := c.calls.LoadAndDelete(seq); ok {
+			close(val.(*pendingCall).reply)
+		}
+
+		return true
+	})
+}
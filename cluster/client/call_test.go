@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	xjson "github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/packet"
+)
+
+type callReply struct {
+	Message string `json:"message"`
+}
+
+func newCallClient() (*Client, *mockConn, *Conn) {
+	c := NewClient(WithCodec(xjson.DefaultCodec))
+	conn := newMockConn(1)
+	cc := newConn(c, conn, &dialOptions{})
+	c.conns.Store(conn, cc)
+
+	return c, conn, cc
+}
+
+// 收到客户端推送的请求后依次投递回复消息
+func respond(c *Client, conn *mockConn, replies ...[]byte) {
+	go func() {
+		<-conn.pushed
+
+		for _, reply := range replies {
+			c.handleReceive(conn, reply)
+		}
+	}()
+}
+
+func packReply(t *testing.T, seq, route int32, message string) []byte {
+	t.Helper()
+
+	buf, err := json.Marshal(&callReply{Message: message})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := packet.PackMessage(&packet.Message{Seq: seq, Route: route, Buffer: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestCall(t *testing.T) {
+	c, conn, cc := newCallClient()
+
+	handled := make(chan string, 2)
+	c.addRouteHandler(1, func(ctx *Context) {
+		handled <- string(ctx.message.Buffer)
+	})
+	c.addRouteHandler(2, func(ctx *Context) {
+		handled <- string(ctx.message.Buffer)
+	})
+
+	// 路由号不匹配的消息交由路由处理器处理，重复的回复消息同理
+	respond(c, conn,
+		packReply(t, 1, 2, "mismatch"),
+		packReply(t, 1, 1, "pong"),
+		packReply(t, 1, 1, "duplicate"),
+	)
+
+	reply := &callReply{}
+
+	if err := cc.Call(context.Background(), 1, &callReply{Message: "ping"}, reply); err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.Message != "pong" {
+		t.Fatalf("reply mismatch, want: pong got: %s", reply.Message)
+	}
+
+	for _, want := range []string{"mismatch", "duplicate"} {
+		select {
+		case got := <-handled:
+			if reply = (&callReply{}); json.Unmarshal([]byte(got), reply) != nil || reply.Message != want {
+				t.Fatalf("route handler message mismatch, want: %s got: %s", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("message %s is not handled by route handler", want)
+		}
+	}
+}
+
+func TestCall_Timeout(t *testing.T) {
+	_, conn, cc := newCallClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := cc.Call(ctx, 1, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("call error mismatch, want: %v got: %v", context.DeadlineExceeded, err)
+	}
+
+	if len(conn.pushed) != 1 {
+		t.Fatalf("request is not pushed, pushed: %d", len(conn.pushed))
+	}
+
+	cc.calls.Range(func(seq, _ any) bool {
+		t.Fatalf("pending call %v is not deleted after timeout", seq)
+		return false
+	})
+}
+
+func TestCall_Disconnect(t *testing.T) {
+	c, conn, cc := newCallClient()
+
+	go func() {
+		<-conn.pushed
+		c.handleDisconnect(conn)
+	}()
+
+	if err := cc.Call(context.Background(), 1, nil, nil); !errors.Is(err, errors.ErrConnectionClosed) {
+		t.Fatalf("call error mismatch, want: %v got: %v", errors.ErrConnectionClosed, err)
+	}
+}
+
+func TestCall_SeqOverflow(t *testing.T) {
+	c, conn, cc := newCallClient()
+
+	// 超出默认打包器的2字节序列号后重新从1开始分配
+	cc.seq.Store(1<<15 - 1)
+
+	respond(c, conn, packReply(t, 1, 1, "pong"))
+
+	if err := cc.Call(context.Background(), 1, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if seq := cc.seq.Load(); seq != 1 {
+		t.Fatalf("seq is not reset after overflow, seq: %d", seq)
+	}
+}
@@ -105,16 +105,31 @@ func (c *Client) handleDisconnect(conn network.Conn) {
 
 	c.conns.Delete(conn)
 
-	handlers, ok := c.events[cluster.Disconnect]
-	if !ok {
+	cc := val.(*Conn)
+
+	cc.cancelCalls()
+
+	// 重连过程中握手失败的连接，由重连流程继续处理
+	if cc.redial.Load() {
 		return
 	}
 
-	for _, handler := range handlers {
-		xcall.Call(func() {
-			handler(val.(*Conn))
-		})
+	if cc.opts.backoff != nil && !cc.closed.Load() && c.getState() != cluster.Shut {
+		cc.redial.Store(true)
+
+		xcall.Go(func() { c.reconnect(cc, conn) })
+
+		return
 	}
+
+	c.doDisconnect(cc)
+}
+
+// 触发断开连接事件
+func (c *Client) doDisconnect(cc *Conn) {
+	cc.redial.Store(false)
+
+	c.fireEvent(cluster.Disconnect, cc)
 }
 
 // 处理接收到的消息
@@ -124,12 +139,36 @@ func (c *Client) handleReceive(conn network.Conn, data []byte) {
 		return
 	}
 
+	if isBatch, err := packet.CheckBatch(data); err == nil && isBatch {
+		packets, err := packet.UnpackBatch(data)
+		if err != nil {
+			log.Errorf("unpack batch message failed: %v", err)
+			return
+		}
+
+		for _, p := range packets {
+			c.handleReceive(conn, p)
+		}
+
+		return
+	}
+
 	message, err := packet.UnpackMessage(data)
 	if err != nil {
 		log.Errorf("unpack message failed: %v", err)
 		return
 	}
 
+	if c.opts.reliable && message.Route == c.opts.reliableRoute {
+		if message, ok = c.receiveReliable(val.(*Conn), message); !ok {
+			return
+		}
+	}
+
+	if val.(*Conn).resolve(message) {
+		return
+	}
+
 	handlers, ok := c.routes[message.Route]
 	if ok {
 		for _, handler := range handlers {
@@ -168,7 +207,7 @@ func (c *Client) dial(opts ...DialOption) (*Conn, error) {
 		return nil, err
 	}
 
-	cc := &Conn{conn: conn, client: c}
+	cc := newConn(c, conn, o)
 
 	for key, value := range o.attrs {
 		cc.SetAttr(key, value)
@@ -176,14 +215,14 @@ func (c *Client) dial(opts ...DialOption) (*Conn, error) {
 
 	c.conns.Store(conn, cc)
 
-	if handlers, ok := c.events[cluster.Connect]; ok {
-		for _, handler := range handlers {
-			xcall.Call(func() {
-				handler(cc)
-			})
-		}
+	if err = c.handshake(cc); err != nil {
+		c.conns.Delete(conn)
+		_ = conn.Close()
+		return nil, err
 	}
 
+	c.fireEvent(cluster.Connect, cc)
+
 	return cc, nil
 }
 
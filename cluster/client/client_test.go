@@ -0,0 +1,118 @@
+package client
+
+import (
+	"net"
+	"sync"
+
+	"github.com/dobyte/due/v2/network"
+)
+
+type mockAttr struct {
+	values sync.Map
+}
+
+func (a *mockAttr) Set(key, value any) {
+	a.values.Store(key, value)
+}
+
+func (a *mockAttr) Get(key any) (any, bool) {
+	return a.values.Load(key)
+}
+
+func (a *mockAttr) Del(key any) bool {
+	_, ok := a.values.LoadAndDelete(key)
+	return ok
+}
+
+func (a *mockAttr) Visit(fn func(key, value any) bool) {
+	a.values.Range(fn)
+}
+
+type mockConn struct {
+	mu     sync.Mutex
+	id     int64
+	uid    int64
+	attr   mockAttr
+	pushed chan []byte
+	closed bool
+}
+
+func newMockConn(id int64) *mockConn {
+	return &mockConn{id: id, pushed: make(chan []byte, 64)}
+}
+
+func (c *mockConn) ID() int64 {
+	return c.id
+}
+
+func (c *mockConn) UID() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.uid
+}
+
+func (c *mockConn) Attr() network.Attr {
+	return &c.attr
+}
+
+func (c *mockConn) Bind(uid int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.uid = uid
+}
+
+func (c *mockConn) Unbind() {
+	c.Bind(0)
+}
+
+func (c *mockConn) Send(msg []byte) error {
+	return c.Push(msg)
+}
+
+func (c *mockConn) Push(msg []byte) error {
+	c.pushed <- msg
+
+	return nil
+}
+
+func (c *mockConn) State() network.ConnState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return network.ConnClosed
+	}
+
+	return network.ConnOpened
+}
+
+func (c *mockConn) Close(force ...bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	return nil
+}
+
+func (c *mockConn) LocalIP() (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (c *mockConn) LocalAddr() (net.Addr, error) {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil
+}
+
+func (c *mockConn) RemoteIP() (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (c *mockConn) RemoteAddr() (net.Addr, error) {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil
+}
+
+func (c *mockConn) QueueDepth() int {
+	return len(c.pushed)
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"net"
+	"sync"
+	"sync/atomic"
 
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/core/value"
@@ -10,38 +12,66 @@ import (
 )
 
 type Conn struct {
-	conn   network.Conn
-	client *Client
+	conn     atomic.Value // 网络连接，自动重连后将被替换为新的连接
+	client   *Client
+	opts     *dialOptions  // 拨号选项
+	closed   atomic.Bool   // 是否已主动关闭
+	redial   atomic.Bool   // 是否正在重连
+	epoch    atomic.Uint32 // 可靠消息所属的网关发件箱纪元
+	received atomic.Uint32 // 已接收的可靠消息最大序列号
+	seq      atomic.Int32  // 请求序列号
+	calls    sync.Map      // 等待回复的请求（序列号 -> 请求）
+}
+
+func newConn(client *Client, conn network.Conn, opts *dialOptions) *Conn {
+	c := &Conn{client: client, opts: opts}
+	c.store(conn)
+
+	return c
+}
+
+type netConn struct {
+	network.Conn
+}
+
+// 保存网络连接
+func (c *Conn) store(conn network.Conn) {
+	c.conn.Store(netConn{conn})
+}
+
+// 加载网络连接
+func (c *Conn) load() network.Conn {
+	return c.conn.Load().(netConn).Conn
 }
 
 // ID 获取连接ID
 func (c *Conn) ID() int64 {
-	return c.conn.ID()
+	return c.load().ID()
 }
 
 // UID 获取用户ID
 func (c *Conn) UID() int64 {
-	return c.conn.UID()
+	return c.load().UID()
 }
 
 // Bind 绑定用户ID
 func (c *Conn) Bind(uid int64) {
-	c.conn.Bind(uid)
+	c.load().Bind(uid)
 }
 
 // Unbind 解绑用户ID
 func (c *Conn) Unbind() {
-	c.conn.Unbind()
+	c.load().Unbind()
 }
 
 // SetAttr 设置属性值
 func (c *Conn) SetAttr(key, value any) {
-	c.conn.Attr().Set(key, value)
+	c.load().Attr().Set(key, value)
 }
 
 // GetAttr 获取属性值
 func (c *Conn) GetAttr(key any) value.Value {
-	if val, ok := c.conn.Attr().Get(key); ok {
+	if val, ok := c.load().Attr().Get(key); ok {
 		return value.NewValue(val)
 	} else {
 		return value.NewValue()
@@ -50,34 +80,35 @@ func (c *Conn) GetAttr(key any) value.Value {
 
 // DelAttr 删除属性值
 func (c *Conn) DelAttr(key any) {
-	c.conn.Attr().Del(key)
+	c.load().Attr().Del(key)
 }
 
 // LocalIP 获取本地IP
 func (c *Conn) LocalIP() (string, error) {
-	return c.conn.LocalIP()
+	return c.load().LocalIP()
 }
 
 // LocalAddr 获取本地地址
 func (c *Conn) LocalAddr() (net.Addr, error) {
-	return c.conn.LocalAddr()
+	return c.load().LocalAddr()
 }
 
 // RemoteIP 获取远端IP
 func (c *Conn) RemoteIP() (string, error) {
-	return c.conn.RemoteIP()
+	return c.load().RemoteIP()
 }
 
 // RemoteAddr 获取远端地址
 func (c *Conn) RemoteAddr() (net.Addr, error) {
-	return c.conn.RemoteAddr()
+	return c.load().RemoteAddr()
 }
 
 // Push 推送消息
 func (c *Conn) Push(message *cluster.Message) error {
 	var (
-		err    error
-		buffer []byte
+		err        error
+		buffer     []byte
+		compressed bool
 	)
 
 	if message.Data != nil {
@@ -91,6 +122,12 @@ func (c *Conn) Push(message *cluster.Message) error {
 		}
 
 		if c.client.opts.encryptor != nil {
+			// 加密后的数据无法被有效压缩，因此先压缩再加密
+			buffer, compressed, err = packet.CompressBuffer(buffer)
+			if err != nil {
+				return err
+			}
+
 			buffer, err = c.client.opts.encryptor.Encrypt(buffer)
 			if err != nil {
 				return err
@@ -99,18 +136,22 @@ func (c *Conn) Push(message *cluster.Message) error {
 	}
 
 	msg, err := packet.PackMessage(&packet.Message{
-		Seq:    message.Seq,
-		Route:  message.Route,
-		Buffer: buffer,
+		Seq:        message.Seq,
+		Route:      message.Route,
+		Buffer:     buffer,
+		Headers:    message.Headers,
+		Compressed: compressed,
 	})
 	if err != nil {
 		return err
 	}
 
-	return c.conn.Push(msg)
+	return c.load().Push(msg)
 }
 
-// Close 关闭连接
+// Close 关闭连接，主动关闭的连接不会自动重连
 func (c *Conn) Close() error {
-	return c.conn.Close()
+	c.closed.Store(true)
+
+	return c.load().Close()
 }
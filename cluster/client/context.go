@@ -42,6 +42,16 @@ func (c *Context) Route() int32 {
 	return c.message.Route
 }
 
+// Header 获取消息扩展头
+func (c *Context) Header(key string) string {
+	return c.message.Headers[key]
+}
+
+// Headers 获取消息的所有扩展头
+func (c *Context) Headers() map[string]string {
+	return c.message.Headers
+}
+
 // Data 获取消息数据
 func (c *Context) Data() any {
 	return c.message.Buffer
@@ -56,6 +66,12 @@ func (c *Context) Parse(v any) (err error) {
 		if err != nil {
 			return
 		}
+
+		if c.message.Compressed {
+			if buffer, err = packet.DecompressBuffer(buffer); err != nil {
+				return
+			}
+		}
 	}
 
 	return c.conn.client.opts.codec.Unmarshal(buffer, v)
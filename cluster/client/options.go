@@ -27,13 +27,15 @@ const (
 type Option func(o *options)
 
 type options struct {
-	id        string           // 实例ID
-	name      string           // 实例名称
-	ctx       context.Context  // 上下文
-	codec     encoding.Codec   // 编解码器
-	client    network.Client   // 网络客户端
-	timeout   time.Duration    // RPC调用超时时间
-	encryptor crypto.Encryptor // 消息加密器
+	id            string           // 实例ID
+	name          string           // 实例名称
+	ctx           context.Context  // 上下文
+	codec         encoding.Codec   // 编解码器
+	client        network.Client   // 网络客户端
+	timeout       time.Duration    // RPC调用超时时间
+	encryptor     crypto.Encryptor // 消息加密器
+	reliable      bool             // 是否开启可靠投递
+	reliableRoute int32            // 可靠投递路由
 }
 
 func defaultOptions() *options {
@@ -100,11 +102,19 @@ func WithEncryptor(encryptor crypto.Encryptor) Option {
 	return func(o *options) { o.encryptor = encryptor }
 }
 
+// WithReliableRoute 设置可靠投递路由，需与网关的可靠投递路由保持一致
+// 开启后客户端将自动解包网关推送的可靠消息、过滤重复消息并回复确认
+func WithReliableRoute(route int32) Option {
+	return func(o *options) { o.reliable, o.reliableRoute = true, route }
+}
+
 type DialOption func(o *dialOptions)
 
 type dialOptions struct {
-	addr  string
-	attrs map[string]any
+	addr      string
+	attrs     map[string]any
+	backoff   *Backoff
+	handshake func(conn *Conn) error
 }
 
 // WithDialAddr 设置拨号地址
@@ -116,3 +126,21 @@ func WithDialAddr(addr string) DialOption {
 func WithConnAttr(key string, value any) DialOption {
 	return func(o *dialOptions) { o.attrs[key] = value }
 }
+
+// WithDialReconnect 设置自动重连
+// 连接非主动断开时将按照退避策略自动重连，重连成功后触发cluster.Reconnect事件，重连失败后触发cluster.Disconnect事件
+// 重连期间*Conn保持不变，连接属性与绑定的用户ID将迁移到新的连接上
+func WithDialReconnect(backoff *Backoff) DialOption {
+	return func(o *dialOptions) {
+		if backoff == nil {
+			backoff = &Backoff{}
+		}
+		o.backoff = backoff
+	}
+}
+
+// WithDialHandshake 设置握手函数
+// 握手函数在拨号成功及每次重连成功后执行，可用于发送鉴权消息或会话恢复令牌，握手失败时连接将被关闭
+func WithDialHandshake(handshake func(conn *Conn) error) DialOption {
+	return func(o *dialOptions) { o.handshake = handshake }
+}
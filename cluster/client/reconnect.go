@@ -0,0 +1,161 @@
+package client
+
+import (
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/utils/xcall"
+	"github.com/dobyte/due/v2/utils/xrand"
+)
+
+const (
+	defaultBackoffInitial    = 500 * time.Millisecond // 默认初始重连间隔
+	defaultBackoffMax        = 30 * time.Second       // 默认最大重连间隔
+	defaultBackoffMultiplier = 2                      // 默认重连间隔增长倍数
+)
+
+// Backoff 自动重连的退避策略
+type Backoff struct {
+	// 初始重连间隔，默认为500ms
+	Initial time.Duration
+
+	// 最大重连间隔，默认为30s
+	Max time.Duration
+
+	// 重连间隔增长倍数，默认为2
+	Multiplier float64
+
+	// 重连间隔随机抖动比例，取值范围[0,1]，默认为0不抖动
+	Jitter float64
+
+	// 最大重连次数，默认为0不限制
+	MaxAttempts int
+
+	// 重连的最长持续时间，默认为0不限制
+	Timeout time.Duration
+}
+
+// 计算下一次重连间隔
+func (b *Backoff) next(delay time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 1 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	maxDelay := b.Max
+	if maxDelay <= 0 {
+		maxDelay = defaultBackoffMax
+	}
+
+	if delay <= 0 {
+		if delay = b.Initial; delay <= 0 {
+			delay = defaultBackoffInitial
+		}
+	} else {
+		delay = time.Duration(float64(delay) * multiplier)
+	}
+
+	return min(delay, maxDelay)
+}
+
+// 为重连间隔添加随机抖动
+func (b *Backoff) jitter(delay time.Duration) time.Duration {
+	if b.Jitter <= 0 {
+		return delay
+	}
+
+	jitter := min(b.Jitter, 1)
+
+	return time.Duration(float64(delay) * xrand.Float64(1-jitter, 1+jitter))
+}
+
+// 建立连接后执行握手
+func (c *Client) handshake(cc *Conn) error {
+	if cc.opts.handshake == nil {
+		return nil
+	}
+
+	return cc.opts.handshake(cc)
+}
+
+// 自动重连，重连成功后将新的网络连接替换到原连接上并触发重连事件
+// 重连失败时触发断开连接事件
+func (c *Client) reconnect(cc *Conn, old network.Conn) {
+	var (
+		backoff  = cc.opts.backoff
+		delay    time.Duration
+		deadline time.Time
+	)
+
+	if backoff.Timeout > 0 {
+		deadline = time.Now().Add(backoff.Timeout)
+	}
+
+	for attempt := 1; backoff.MaxAttempts <= 0 || attempt <= backoff.MaxAttempts; attempt++ {
+		delay = backoff.next(delay)
+
+		select {
+		case <-c.ctx.Done():
+			c.doDisconnect(cc)
+			return
+		case <-time.After(backoff.jitter(delay)):
+		}
+
+		if c.getState() == cluster.Shut || cc.closed.Load() {
+			break
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+
+		conn, err := c.opts.client.Dial(cc.opts.addr)
+		if err != nil {
+			log.Warnf("reconnect failed, addr: %s attempt: %d err: %v", cc.opts.addr, attempt, err)
+			continue
+		}
+
+		old.Attr().Visit(func(key, value any) bool {
+			conn.Attr().Set(key, value)
+			return true
+		})
+
+		if uid := old.UID(); uid != 0 {
+			conn.Bind(uid)
+		}
+
+		cc.store(conn)
+
+		c.conns.Store(conn, cc)
+
+		if err = c.handshake(cc); err != nil {
+			log.Warnf("reconnect handshake failed, addr: %s attempt: %d err: %v", cc.opts.addr, attempt, err)
+			_ = conn.Close()
+			continue
+		}
+
+		cc.redial.Store(false)
+
+		c.fireEvent(cluster.Reconnect, cc)
+
+		return
+	}
+
+	c.doDisconnect(cc)
+}
+
+// 触发连接事件
+func (c *Client) fireEvent(event cluster.Event, cc *Conn) {
+	handlers, ok := c.events[event]
+	if !ok {
+		return
+	}
+
+	for _, handler := range handlers {
+		xcall.Call(func() {
+			handler(cc)
+		})
+	}
+}
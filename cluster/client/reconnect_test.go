@@ -0,0 +1,231 @@
+package client
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/network"
+)
+
+type mockClient struct {
+	mu    sync.Mutex
+	dials int
+	fail  func(dials int) bool
+}
+
+func (c *mockClient) Dial(_ ...string) (network.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dials++
+
+	if c.fail != nil && c.fail(c.dials) {
+		return nil, errors.New("dial failed")
+	}
+
+	return newMockConn(int64(c.dials)), nil
+}
+
+func (c *mockClient) attempts() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.dials
+}
+
+func (c *mockClient) Protocol() string { return "mock" }
+
+func (c *mockClient) OnConnect(_ network.ConnectHandler) {}
+
+func (c *mockClient) OnReceive(_ network.ReceiveHandler) {}
+
+func (c *mockClient) OnDisconnect(_ network.DisconnectHandler) {}
+
+// 构建处于工作状态的客户端，并记录连接事件
+func newReconnectClient(fail func(dials int) bool) (*Client, *mockClient, chan cluster.Event) {
+	mc := &mockClient{fail: fail}
+	c := NewClient(WithClient(mc))
+	events := make(chan cluster.Event, 4)
+
+	for _, event := range []cluster.Event{cluster.Connect, cluster.Reconnect, cluster.Disconnect} {
+		event := event
+		c.addEventListener(event, func(conn *Conn) { events <- event })
+	}
+
+	c.setState(cluster.Work)
+
+	return c, mc, events
+}
+
+func expectEvent(t *testing.T, events chan cluster.Event, want cluster.Event) {
+	t.Helper()
+
+	select {
+	case got := <-events:
+		if got != want {
+			t.Fatalf("event mismatch, want: %v got: %v", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("wait event %v timeout", want)
+	}
+}
+
+func TestBackoff_Next(t *testing.T) {
+	b := &Backoff{}
+
+	var delay time.Duration
+
+	for _, want := range []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second} {
+		if delay = b.next(delay); delay != want {
+			t.Fatalf("default delay mismatch, want: %v got: %v", want, delay)
+		}
+	}
+
+	b = &Backoff{Initial: 100 * time.Millisecond, Max: 250 * time.Millisecond, Multiplier: 1.5}
+	delay = 0
+
+	for _, want := range []time.Duration{100 * time.Millisecond, 150 * time.Millisecond, 225 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond} {
+		if delay = b.next(delay); delay != want {
+			t.Fatalf("custom delay mismatch, want: %v got: %v", want, delay)
+		}
+	}
+}
+
+func TestBackoff_Jitter(t *testing.T) {
+	const delay = time.Second
+
+	if got := (&Backoff{}).jitter(delay); got != delay {
+		t.Fatalf("delay should not be jittered, got: %v", got)
+	}
+
+	b := &Backoff{Jitter: 0.2}
+
+	for i := 0; i < 100; i++ {
+		if got := b.jitter(delay); got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("jittered delay out of range: %v", got)
+		}
+	}
+
+	// 抖动比例超出1时按1处理
+	b = &Backoff{Jitter: 5}
+
+	for i := 0; i < 100; i++ {
+		if got := b.jitter(delay); got < 0 || got > 2*delay {
+			t.Fatalf("jittered delay out of range: %v", got)
+		}
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	// 首次重连拨号失败
+	c, mc, events := newReconnectClient(func(dials int) bool { return dials == 2 })
+
+	var handshakes atomic.Int32
+
+	cc, err := c.dial(
+		WithDialAddr("127.0.0.1:3553"),
+		WithConnAttr("name", "due"),
+		WithDialReconnect(&Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond}),
+		WithDialHandshake(func(conn *Conn) error {
+			// 第二次重连的首次握手失败
+			if handshakes.Add(1) == 2 {
+				return errors.New("handshake failed")
+			}
+
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectEvent(t, events, cluster.Connect)
+
+	old := cc.load()
+	cc.Bind(1)
+
+	c.handleDisconnect(old)
+
+	expectEvent(t, events, cluster.Reconnect)
+
+	if attempts := mc.attempts(); attempts != 4 {
+		t.Fatalf("dial attempts mismatch, want: 4 got: %d", attempts)
+	}
+
+	if handshakes.Load() != 3 {
+		t.Fatalf("handshakes mismatch, want: 3 got: %d", handshakes.Load())
+	}
+
+	conn := cc.load()
+
+	if conn == old || conn.ID() != 4 {
+		t.Fatalf("connection is not replaced, id: %d", conn.ID())
+	}
+
+	if cc.UID() != 1 || cc.GetAttr("name").String() != "due" {
+		t.Fatalf("uid or attrs are not migrated, uid: %d name: %s", cc.UID(), cc.GetAttr("name").String())
+	}
+
+	if _, ok := c.conns.Load(old); ok {
+		t.Fatal("old connection is not removed")
+	}
+
+	if val, ok := c.conns.Load(conn); !ok || val.(*Conn) != cc {
+		t.Fatal("new connection is not stored")
+	}
+
+	if cc.redial.Load() {
+		t.Fatal("redial flag is not reset after reconnected")
+	}
+}
+
+func TestReconnect_MaxAttempts(t *testing.T) {
+	c, mc, events := newReconnectClient(func(dials int) bool { return dials > 1 })
+
+	cc, err := c.dial(WithDialReconnect(&Backoff{Initial: time.Millisecond, MaxAttempts: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectEvent(t, events, cluster.Connect)
+
+	c.handleDisconnect(cc.load())
+
+	expectEvent(t, events, cluster.Disconnect)
+
+	if attempts := mc.attempts(); attempts != 3 {
+		t.Fatalf("dial attempts mismatch, want: 3 got: %d", attempts)
+	}
+
+	if cc.redial.Load() {
+		t.Fatal("redial flag is not reset after reconnect failed")
+	}
+}
+
+func TestReconnect_Closed(t *testing.T) {
+	c, mc, events := newReconnectClient(nil)
+
+	cc, err := c.dial(WithDialReconnect(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectEvent(t, events, cluster.Connect)
+
+	// 主动关闭的连接不进行重连
+	if err = cc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.handleDisconnect(cc.load())
+
+	expectEvent(t, events, cluster.Disconnect)
+
+	if attempts := mc.attempts(); attempts != 1 {
+		t.Fatalf("closed connection should not reconnect, dial attempts: %d", attempts)
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"encoding/binary"
+
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/packet"
+)
+
+const (
+	reliableEpochBytes  = 4
+	reliableSeqBytes    = 4
+	reliableHeaderBytes = reliableEpochBytes + reliableSeqBytes
+)
+
+// 处理网关推送的可靠消息，返回携带的原始消息
+// 已接收过的重复消息只进行确认，返回false；网关开启新的发件箱后，已接收的序列号将被重置
+func (c *Client) receiveReliable(conn *Conn, message *packet.Message) (*packet.Message, bool) {
+	if len(message.Buffer) < reliableHeaderBytes {
+		log.Errorf("invalid reliable message, route: %v", message.Route)
+		return nil, false
+	}
+
+	epoch := binary.BigEndian.Uint32(message.Buffer)
+	seq := binary.BigEndian.Uint32(message.Buffer[reliableEpochBytes:])
+
+	msg, err := packet.UnpackMessage(message.Buffer[reliableHeaderBytes:])
+	if err != nil {
+		log.Errorf("unpack reliable message failed: %v", err)
+		return nil, false
+	}
+
+	if epoch != conn.epoch.Load() {
+		conn.epoch.Store(epoch)
+		conn.received.Store(0)
+	}
+
+	duplicate := seq <= conn.received.Load()
+
+	if !duplicate {
+		conn.received.Store(seq)
+	}
+
+	if err = conn.ack(); err != nil {
+		log.Warnf("ack reliable message failed, seq: %v err: %v", seq, err)
+	}
+
+	return msg, !duplicate
+}
+
+// 确认已接收的可靠消息
+func (c *Conn) ack() error {
+	buf := make([]byte, reliableHeaderBytes)
+	binary.BigEndian.PutUint32(buf, c.epoch.Load())
+	binary.BigEndian.PutUint32(buf[reliableEpochBytes:], c.received.Load())
+
+	msg, err := packet.PackMessage(&packet.Message{
+		Route:  c.client.opts.reliableRoute,
+		Buffer: buf,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.load().Push(msg)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/dobyte/due/v2/packet"
+)
+
+const testReliableRoute = 100
+
+func packReliable(t *testing.T, epoch, seq uint32, route int32) []byte {
+	t.Helper()
+
+	msg, err := packet.PackMessage(&packet.Message{Route: route, Buffer: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, reliableHeaderBytes+len(msg))
+	binary.BigEndian.PutUint32(buf, epoch)
+	binary.BigEndian.PutUint32(buf[reliableEpochBytes:], seq)
+	copy(buf[reliableHeaderBytes:], msg)
+
+	data, err := packet.PackMessage(&packet.Message{Route: testReliableRoute, Buffer: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func expectAck(t *testing.T, conn *mockConn, epoch, seq uint32) {
+	t.Helper()
+
+	select {
+	case data := <-conn.pushed:
+		msg, err := packet.UnpackMessage(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if msg.Route != testReliableRoute || len(msg.Buffer) != reliableHeaderBytes {
+			t.Fatalf("invalid ack message: %+v", msg)
+		}
+
+		gotEpoch := binary.BigEndian.Uint32(msg.Buffer)
+		gotSeq := binary.BigEndian.Uint32(msg.Buffer[reliableEpochBytes:])
+
+		if gotEpoch != epoch || gotSeq != seq {
+			t.Fatalf("ack mismatch, want: %d/%d got: %d/%d", epoch, seq, gotEpoch, gotSeq)
+		}
+	default:
+		t.Fatal("ack is not sent")
+	}
+}
+
+func TestReceiveReliable(t *testing.T) {
+	c := NewClient(WithReliableRoute(testReliableRoute))
+
+	received := make([]int32, 0)
+	c.addRouteHandler(1, func(ctx *Context) {
+		received = append(received, ctx.Route())
+	})
+
+	conn := newMockConn(1)
+	c.conns.Store(conn, newConn(c, conn, &dialOptions{}))
+
+	c.handleReceive(conn, packReliable(t, 1, 1, 1))
+	expectAck(t, conn, 1, 1)
+
+	c.handleReceive(conn, packReliable(t, 1, 2, 1))
+	expectAck(t, conn, 1, 2)
+
+	// 重复消息仅确认，不进行处理
+	c.handleReceive(conn, packReliable(t, 1, 1, 1))
+	expectAck(t, conn, 1, 2)
+
+	if len(received) != 2 {
+		t.Fatalf("received count mismatch, want: 2 got: %d", len(received))
+	}
+
+	// 网关开启新的发件箱后，序列号从1重新开始
+	c.handleReceive(conn, packReliable(t, 2, 1, 1))
+	expectAck(t, conn, 2, 1)
+
+	if len(received) != 3 {
+		t.Fatalf("message of new epoch is dropped, received: %d", len(received))
+	}
+}
@@ -102,18 +102,30 @@ const (
 	Random           Dispatch = "random" // 随机
 	RoundRobin       Dispatch = "rr"     // 轮询
 	WeightRoundRobin Dispatch = "wrr"    // 加权轮询
+	ConsistentHash   Dispatch = "hash"   // 按用户ID一致性哈希
+	LeastLoad        Dispatch = "least"  // 最小负载
 )
 
+// LoadMetadataKey 实例负载在服务实例元数据中的键，最小负载分发策略将选择负载最低的实例
+const LoadMetadataKey = "load"
+
 type GetIPArgs struct {
 	GID    string       // 网关ID，会话类型为用户时可忽略此参数
 	Kind   session.Kind // 会话类型，session.Conn 或 session.User
 	Target int64        // 会话目标，CID 或 UID
 }
 
+type GetChannelsArgs struct {
+	GID    string       // 网关ID，会话类型为用户时可忽略此参数
+	Kind   session.Kind // 会话类型，session.Conn 或 session.User
+	Target int64        // 会话目标，CID 或 UID
+}
+
 type Message struct {
-	Seq   int32 // 序列号
-	Route int32 // 路由ID
-	Data  any   // 消息数据，接收json、proto、[]byte
+	Seq     int32             // 序列号
+	Route   int32             // 路由ID
+	Data    any               // 消息数据，接收json、proto、[]byte
+	Headers map[string]string // 扩展头
 }
 
 type PushArgs struct {
@@ -178,3 +190,10 @@ type DeliverArgs struct {
 	UID     int64    // 用户ID
 	Message *Message // 消息
 }
+
+type TellArgs struct {
+	NID     string   // 接收节点。存在接收节点时，消息会直接投递给接收节点；不存在接收节点时，系统定位Actor所在节点，然后投递。
+	PID     string   // 接收Actor的PID，格式为kind/id
+	UID     int64    // 用户ID
+	Message *Message // 消息
+}
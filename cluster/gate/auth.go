@@ -0,0 +1,115 @@
+package gate
+
+import (
+	"context"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+)
+
+// Authenticator 连接鉴权器
+// 连接建立后收到的第一个数据包将交由鉴权器校验（如JWT、基于crypto.Signer签名的HMAC令牌等），校验通过后返回用户ID
+// 返回的错误携带错误码时，使用该错误码作为鉴权失败的原因，否则使用codes.Unauthorized
+type Authenticator func(conn network.Conn, data []byte) (uid int64, err error)
+
+// AuthFailedHandler 鉴权失败处理器，用于将鉴权失败的原因回复给客户端，处理完成后连接将被关闭
+type AuthFailedHandler = CodeReplyHandler
+
+// 检测连接是否已完成握手
+// 开启鉴权或会话恢复时，连接需通过第一个数据包完成鉴权或会话恢复后方可与节点进行通信
+func (g *Gate) isEstablished(cid int64) bool {
+	if g.opts.authenticator == nil && !g.isResumable() {
+		return true
+	}
+
+	_, ok := g.established.Load(cid)
+
+	return ok
+}
+
+// 处理连接握手，返回true表示数据包需继续投递给节点处理
+func (g *Gate) handshake(conn network.Conn, data []byte) bool {
+	if g.isResumable() {
+		if route, err := packet.ExtractRoute(data); err == nil && route == g.opts.resumeRoute {
+			if msg, err := packet.UnpackMessage(data); err == nil {
+				g.resume(conn, data, string(msg.Buffer))
+			} else {
+				g.rejectConn(conn, data, codes.Unauthorized, err)
+			}
+
+			return false
+		}
+	}
+
+	if g.opts.authenticator != nil {
+		g.authenticate(conn, data)
+		return false
+	}
+
+	cid, uid := conn.ID(), conn.UID()
+
+	g.established.Store(cid, struct{}{})
+
+	ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
+	g.proxy.trigger(ctx, cluster.Connect, cid, uid)
+	cancel()
+
+	return true
+}
+
+// 鉴权连接，鉴权通过后在会话中绑定用户ID并向节点触发连接事件
+func (g *Gate) authenticate(conn network.Conn, data []byte) {
+	cid := conn.ID()
+
+	uid, err := g.opts.authenticator(conn, data)
+	if err != nil || uid <= 0 {
+		code := errors.Code(err)
+		if code == nil {
+			code = codes.Unauthorized
+		}
+
+		g.rejectConn(conn, data, code, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
+	defer cancel()
+
+	if err = g.session.Bind(cid, uid); err != nil {
+		g.rejectConn(conn, data, codes.InternalError, err)
+		return
+	}
+
+	if err = g.opts.locator.BindGate(ctx, uid, g.opts.id); err != nil {
+		_, _ = g.session.Unbind(uid)
+		g.rejectConn(conn, data, codes.InternalError, err)
+		return
+	}
+
+	g.established.Store(cid, struct{}{})
+
+	g.openOutbox(uid)
+
+	g.issueResumeToken(cid, uid)
+
+	g.proxy.trigger(ctx, cluster.Connect, cid, uid)
+}
+
+// 拒绝连接
+func (g *Gate) rejectConn(conn network.Conn, data []byte, code *codes.Code, err error) {
+	log.Warnf("connection authenticate failed, cid: %d code: %d err: %v", conn.ID(), code.Code(), err)
+
+	if handler := g.opts.authFailedHandler; handler != nil {
+		g.doReplyCode(handler, conn, data, code)
+	} else {
+		g.replyCode(conn, data, code)
+	}
+
+	if err = conn.Close(); err != nil {
+		log.Warnf("connection close failed, cid: %d err: %v", conn.ID(), err)
+	}
+}
@@ -0,0 +1,276 @@
+package gate
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/locate"
+	"github.com/dobyte/due/v2/locate/memory"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+	registry "github.com/dobyte/due/v2/registry/memory"
+	"github.com/dobyte/due/v2/session"
+)
+
+const authGID = "gate-1"
+
+var errForbidden = codes.NewCode(403, "forbidden")
+
+// 阻塞绑定网关直至超时的定位器
+type blockingLocator struct {
+	*memory.Locator
+}
+
+func (l *blockingLocator) BindGate(ctx context.Context, _ int64, _ string) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+// 构建开启鉴权的网关，携带token的数据包鉴权通过并绑定用户1，携带forbidden的数据包以errForbidden拒绝
+func newAuthGate(locator locate.Locator, opts ...Option) (*Gate, *atomic.Int32) {
+	calls := &atomic.Int32{}
+
+	g := NewGate(append([]Option{
+		WithID(authGID),
+		WithLocator(locator),
+		WithRegistry(registry.NewRegistry()),
+		WithAuthenticator(func(conn network.Conn, data []byte) (int64, error) {
+			calls.Add(1)
+
+			switch {
+			case bytes.Contains(data, []byte("token")):
+				return 1, nil
+			case bytes.Contains(data, []byte("forbidden")):
+				return 0, errors.NewError(errForbidden)
+			default:
+				return 0, errors.New("invalid token")
+			}
+		}),
+	}, opts...)...)
+
+	return g, calls
+}
+
+func packAuth(t *testing.T, route int32, buffer string) []byte {
+	t.Helper()
+
+	data, err := packet.PackMessage(&packet.Message{Seq: 1, Route: route, Buffer: []byte(buffer)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// 校验连接被拒绝并收到指定的错误码
+func expectRejected(t *testing.T, g *Gate, conn *mockConn, code *codes.Code) {
+	t.Helper()
+
+	messages := conn.messages()
+	if len(messages) != 1 {
+		t.Fatalf("rejected connection should be replied, replies: %d", len(messages))
+	}
+
+	msg, err := packet.UnpackMessage(messages[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := cluster.UnmarshalCodeReply(g.opts.codec, msg.Buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.Code != code.Code() {
+		t.Fatalf("code mismatch, want: %d got: %d", code.Code(), reply.Code)
+	}
+
+	if conn.State() != network.ConnClosed {
+		t.Fatal("rejected connection is not closed")
+	}
+
+	if g.isEstablished(conn.ID()) || conn.UID() != 0 {
+		t.Fatalf("rejected connection is established, uid: %d", conn.UID())
+	}
+}
+
+func TestAuthenticate_Accept(t *testing.T) {
+	locator := memory.NewLocator()
+
+	g, calls := newAuthGate(locator)
+	defer g.cancel()
+
+	conn := newMockConn(1)
+	g.handleConnect(conn)
+
+	g.handleReceive(conn, packAuth(t, 1, "token"))
+
+	if calls.Load() != 1 || !g.isEstablished(conn.ID()) {
+		t.Fatalf("connection is not established, authenticate calls: %d", calls.Load())
+	}
+
+	if conn.UID() != 1 || conn.State() == network.ConnClosed || len(conn.messages()) != 0 {
+		t.Fatalf("unexpected connection, uid: %d state: %v replies: %d", conn.UID(), conn.State(), len(conn.messages()))
+	}
+
+	if gid, err := locator.LocateGate(context.Background(), 1); err != nil || gid != authGID {
+		t.Fatalf("user is not bound to gate, gid: %q err: %v", gid, err)
+	}
+
+	// 鉴权通过后的数据包不再交由鉴权器处理
+	g.handleReceive(conn, packAuth(t, 1, "hello"))
+
+	if calls.Load() != 1 {
+		t.Fatalf("established connection is authenticated again, authenticate calls: %d", calls.Load())
+	}
+}
+
+func TestAuthenticate_Reject(t *testing.T) {
+	g, _ := newAuthGate(memory.NewLocator())
+	defer g.cancel()
+
+	for data, code := range map[string]*codes.Code{
+		"invalid":   codes.Unauthorized,
+		"forbidden": errForbidden,
+	} {
+		conn := newMockConn(1)
+		g.handleConnect(conn)
+
+		g.handleReceive(conn, packAuth(t, 1, data))
+
+		expectRejected(t, g, conn, code)
+
+		g.handleDisconnect(conn)
+	}
+}
+
+func TestAuthenticate_Timeout(t *testing.T) {
+	g, _ := newAuthGate(&blockingLocator{Locator: memory.NewLocator()}, WithTimeout(20*time.Millisecond))
+	defer g.cancel()
+
+	conn := newMockConn(1)
+	g.handleConnect(conn)
+
+	start := time.Now()
+
+	// 绑定网关超时后连接被拒绝，已绑定的会话将被解绑
+	g.handleReceive(conn, packAuth(t, 1, "token"))
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("authenticate is not bounded by gate timeout, elapsed: %v", elapsed)
+	}
+
+	expectRejected(t, g, conn, codes.InternalError)
+
+	if ok, _ := g.session.Has(session.User, 1); ok {
+		t.Fatal("user session is not unbound after timeout")
+	}
+}
+
+func TestAuthenticate_FailedHandler(t *testing.T) {
+	var handled atomic.Int32
+
+	g, _ := newAuthGate(memory.NewLocator(), WithAuthFailedHandler(func(ctx *ReplyContext, code *codes.Code) error {
+		handled.Add(1)
+
+		return ctx.Response(&cluster.CodeReply{Code: code.Code(), Message: "custom"})
+	}), WithCodec(json.DefaultCodec))
+	defer g.cancel()
+
+	conn := newMockConn(1)
+	g.handleConnect(conn)
+
+	g.handleReceive(conn, packAuth(t, 1, "forbidden"))
+
+	if handled.Load() != 1 {
+		t.Fatalf("auth failed handler is not invoked, calls: %d", handled.Load())
+	}
+
+	expectRejected(t, g, conn, errForbidden)
+
+	msg, err := packet.UnpackMessage(conn.messages()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply, err := cluster.UnmarshalCodeReply(g.opts.codec, msg.Buffer); err != nil || reply.Message != "custom" {
+		t.Fatalf("reply is not sent by auth failed handler, reply: %+v err: %v", reply, err)
+	}
+}
+
+func TestAuthenticate_DropBeforeAuth(t *testing.T) {
+	g, calls := newAuthGate(memory.NewLocator())
+	defer g.cancel()
+
+	packer := newCountingPacker()
+	packet.SetPacker(packer)
+	defer packet.SetPacker(packet.NewPacker())
+
+	conn := newMockConn(1)
+	g.handleConnect(conn)
+
+	// 未完成鉴权的连接上的业务数据包只用于鉴权，不会投递给节点
+	g.handleReceive(conn, packAuth(t, 5, "hello"))
+
+	if calls.Load() != 1 {
+		t.Fatalf("packet is not handed to authenticator, authenticate calls: %d", calls.Load())
+	}
+
+	if n := packer.unpacks.Load(); n != 0 {
+		t.Fatalf("packet before authentication is delivered, unpacks: %d", n)
+	}
+
+	expectRejected(t, g, conn, codes.Unauthorized)
+}
+
+func TestAuthenticate_ResumeBypass(t *testing.T) {
+	const resumeRoute = 10
+
+	locator := memory.NewLocator()
+
+	g, calls := newAuthGate(locator, WithResumption(time.Minute, resumeRoute))
+	defer g.cancel()
+
+	conn := newMockConn(1)
+	g.handleConnect(conn)
+	g.handleReceive(conn, packAuth(t, 1, "token"))
+
+	token := g.resumption.tokens[1]
+	if token == "" {
+		t.Fatal("resume token is not issued")
+	}
+
+	g.handleDisconnect(conn)
+
+	// 恢复路由的数据包绕过鉴权器，使用恢复令牌恢复会话
+	newConn := newMockConn(2)
+	g.handleConnect(newConn)
+	g.handleReceive(newConn, packAuth(t, resumeRoute, token))
+
+	if calls.Load() != 1 {
+		t.Fatalf("resume packet is handed to authenticator, authenticate calls: %d", calls.Load())
+	}
+
+	if newConn.UID() != 1 || !g.isEstablished(newConn.ID()) {
+		t.Fatalf("session is not resumed, uid: %d", newConn.UID())
+	}
+
+	// 无效的恢复令牌同样不会交由鉴权器处理
+	invalidConn := newMockConn(3)
+	g.handleConnect(invalidConn)
+	g.handleReceive(invalidConn, packAuth(t, resumeRoute, "invalid"))
+
+	if calls.Load() != 1 {
+		t.Fatalf("resume packet is handed to authenticator, authenticate calls: %d", calls.Load())
+	}
+
+	expectRejected(t, g, invalidConn, codes.Unauthorized)
+}
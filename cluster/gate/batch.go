@@ -0,0 +1,171 @@
+package gate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+)
+
+const defaultBatchBytes = 4096 // 默认批量推送的最大字节数
+
+// 批量推送连接
+// 在刷新窗口内推送给同一连接的消息将被合并为一个批量数据包后再写入连接
+type batchConn struct {
+	network.Conn
+	window   time.Duration // 刷新窗口
+	maxBytes int           // 最大字节数，待推送的消息达到该字节数时立即刷新
+	mu       sync.Mutex
+	packets  [][]byte    // 待推送的数据包
+	size     int         // 待推送的字节数
+	timer    *time.Timer // 刷新定时器
+}
+
+// Push 发送消息（异步）
+func (c *batchConn) Push(msg []byte) error {
+	switch c.Conn.State() {
+	case network.ConnHanged:
+		return errors.ErrConnectionHanged
+	case network.ConnClosed:
+		return errors.ErrConnectionClosed
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.packets) > 0 && c.size+len(msg) > c.maxBytes {
+		if err := c.flush(); err != nil {
+			return err
+		}
+	}
+
+	c.packets = append(c.packets, msg)
+	c.size += len(msg)
+
+	if c.size >= c.maxBytes {
+		return c.flush()
+	}
+
+	if c.timer == nil {
+		c.timer = time.AfterFunc(c.window, c.tick)
+	}
+
+	return nil
+}
+
+// Send 发送消息（同步），发送前先刷新待推送的消息以保证消息顺序
+func (c *batchConn) Send(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.flush(); err != nil {
+		return err
+	}
+
+	return c.Conn.Send(msg)
+}
+
+// Close 关闭连接，非强制关闭时先刷新待推送的消息
+func (c *batchConn) Close(force ...bool) error {
+	c.mu.Lock()
+	if len(force) > 0 && force[0] {
+		c.reset()
+	} else if err := c.flush(); err != nil {
+		log.Warnf("flush batch messages failed, cid: %d err: %v", c.ID(), err)
+	}
+	c.mu.Unlock()
+
+	return c.Conn.Close(force...)
+}
+
+// QueueDepth 获取写入队列中待发送的消息数，包含尚未刷新的待推送消息
+func (c *batchConn) QueueDepth() int {
+	c.mu.Lock()
+	n := len(c.packets)
+	c.mu.Unlock()
+
+	return n + c.Conn.QueueDepth()
+}
+
+// 刷新待推送的消息，仅有一条消息时直接推送
+func (c *batchConn) flush() error {
+	packets := c.packets
+
+	c.reset()
+
+	switch len(packets) {
+	case 0:
+		return nil
+	case 1:
+		return c.Conn.Push(packets[0])
+	}
+
+	data, err := packet.MergeBatch(packets...)
+	if err != nil {
+		return err
+	}
+
+	return c.Conn.Push(data)
+}
+
+// 刷新窗口结束
+func (c *batchConn) tick() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.flush(); err != nil {
+		log.Warnf("flush batch messages failed, cid: %d err: %v", c.ID(), err)
+	}
+}
+
+// 重置待推送的消息
+func (c *batchConn) reset() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+
+	c.packets = nil
+	c.size = 0
+}
+
+// 是否开启批量推送，打包器不支持批量数据包时不生效
+func (g *Gate) isBatching() bool {
+	return g.opts.batchWindow > 0 && packet.IsBatchSupported()
+}
+
+// 包装连接，开启批量推送时返回批量推送连接
+func (g *Gate) wrapConn(conn network.Conn) network.Conn {
+	if !g.isBatching() {
+		return conn
+	}
+
+	maxBytes := g.opts.batchBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultBatchBytes
+	}
+
+	bc := &batchConn{Conn: conn, window: g.opts.batchWindow, maxBytes: maxBytes}
+
+	g.batchConns.Store(conn.ID(), bc)
+
+	return bc
+}
+
+// 释放包装的连接，丢弃连接断开后未推送的消息，返回会话中保存的连接
+func (g *Gate) releaseConn(conn network.Conn) network.Conn {
+	val, ok := g.batchConns.LoadAndDelete(conn.ID())
+	if !ok {
+		return conn
+	}
+
+	bc := val.(*batchConn)
+	bc.mu.Lock()
+	bc.reset()
+	bc.mu.Unlock()
+
+	return bc
+}
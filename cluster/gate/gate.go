@@ -14,8 +14,10 @@ import (
 	"sync/atomic"
 
 	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
 	"github.com/dobyte/due/v2/component"
 	"github.com/dobyte/due/v2/core/info"
+	"github.com/dobyte/due/v2/core/limiter"
 	"github.com/dobyte/due/v2/core/net"
 	"github.com/dobyte/due/v2/internal/transporter/gate"
 	"github.com/dobyte/due/v2/log"
@@ -26,15 +28,20 @@ import (
 
 type Gate struct {
 	component.Base
-	opts     *options
-	ctx      context.Context
-	cancel   context.CancelFunc
-	state    atomic.Int32
-	proxy    *proxy
-	instance *registry.ServiceInstance
-	session  *session.Session
-	linker   *gate.Server
-	wg       *sync.WaitGroup
+	opts        *options
+	ctx         context.Context
+	cancel      context.CancelFunc
+	state       atomic.Int32
+	proxy       *proxy
+	instance    *registry.ServiceInstance
+	session     *session.Session
+	linker      *gate.Server
+	wg          *sync.WaitGroup
+	limiters    sync.Map
+	established sync.Map
+	resumption  *resumption
+	reliability *reliability
+	batchConns  sync.Map
 }
 
 func NewGate(opts ...Option) *Gate {
@@ -48,6 +55,8 @@ func NewGate(opts ...Option) *Gate {
 	g.ctx, g.cancel = context.WithCancel(o.ctx)
 	g.proxy = newProxy(g)
 	g.session = session.NewSession()
+	g.resumption = newResumption()
+	g.reliability = newReliability()
 	g.state.Store(int32(cluster.Shut))
 	g.wg = &sync.WaitGroup{}
 
@@ -118,6 +127,8 @@ func (g *Gate) Destroy() {
 
 	g.stopNetworkServer()
 
+	g.expireAll()
+
 	g.stopLinkerServer()
 
 	g.cancel()
@@ -141,11 +152,25 @@ func (g *Gate) stopNetworkServer() {
 	}
 }
 
+// QueueDepth 获取连接或用户写入队列中待发送的消息数，可用于监控慢消费者
+func (g *Gate) QueueDepth(kind session.Kind, target int64) (int, error) {
+	return g.session.QueueDepth(kind, target)
+}
+
 // 处理连接打开
 func (g *Gate) handleConnect(conn network.Conn) {
 	g.wg.Add(1)
 
-	g.session.AddConn(conn)
+	g.session.AddConn(g.wrapConn(conn))
+
+	if g.opts.capacity > 0 {
+		g.limiters.Store(conn.ID(), limiter.NewLimiter(g.opts.capacity, g.opts.rate))
+	}
+
+	// 开启鉴权或会话恢复时，连接事件将在连接完成握手后触发
+	if g.opts.authenticator != nil || g.isResumable() {
+		return
+	}
 
 	cid, uid := conn.ID(), conn.UID()
 
@@ -156,8 +181,27 @@ func (g *Gate) handleConnect(conn network.Conn) {
 
 // 处理断开连接
 func (g *Gate) handleDisconnect(conn network.Conn) {
+	conn = g.releaseConn(conn)
+
+	suspended := g.suspend(conn)
+
 	g.session.RemConn(conn)
 
+	g.limiters.Delete(conn.ID())
+
+	if !g.isEstablished(conn.ID()) {
+		g.wg.Done()
+		return
+	}
+
+	g.established.Delete(conn.ID())
+
+	// 会话已挂起，宽限期结束后再触发断开连接事件
+	if suspended {
+		g.wg.Done()
+		return
+	}
+
 	if cid, uid := conn.ID(), conn.UID(); uid != 0 {
 		ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
 		_ = g.proxy.unbindGate(ctx, cid, uid)
@@ -175,6 +219,25 @@ func (g *Gate) handleDisconnect(conn network.Conn) {
 // 处理接收到的消息
 func (g *Gate) handleReceive(conn network.Conn, data []byte) {
 	cid, uid := conn.ID(), conn.UID()
+
+	receivedMessages.Add(1)
+	receivedBytes.Add(float64(len(data)))
+
+	if !g.isEstablished(cid) && !g.handshake(conn, data) {
+		return
+	}
+
+	if g.acknowledge(conn, data) {
+		return
+	}
+
+	if l, ok := g.limiters.Load(cid); ok && !l.(*limiter.Limiter).Allow() {
+		log.Debugf("deliver message limited, cid: %d uid: %d", cid, uid)
+		limitedMessages.Add(1)
+		g.replyCode(conn, data, codes.TooManyRequests)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
 	g.proxy.deliver(ctx, cid, uid, data)
 	cancel()
@@ -0,0 +1,183 @@
+package gate
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+)
+
+type mockAttr struct {
+	values sync.Map
+}
+
+func (a *mockAttr) Set(key, value any) {
+	a.values.Store(key, value)
+}
+
+func (a *mockAttr) Get(key any) (any, bool) {
+	return a.values.Load(key)
+}
+
+func (a *mockAttr) Del(key any) bool {
+	_, ok := a.values.LoadAndDelete(key)
+	return ok
+}
+
+func (a *mockAttr) Visit(fn func(key, value any) bool) {
+	a.values.Range(fn)
+}
+
+type mockConn struct {
+	mu     sync.Mutex
+	id     int64
+	uid    int64
+	attr   mockAttr
+	pushed [][]byte
+	closed bool
+}
+
+func newMockConn(id int64) *mockConn {
+	return &mockConn{id: id}
+}
+
+func (c *mockConn) ID() int64 {
+	return c.id
+}
+
+func (c *mockConn) UID() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.uid
+}
+
+func (c *mockConn) Attr() network.Attr {
+	return &c.attr
+}
+
+func (c *mockConn) Bind(uid int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.uid = uid
+}
+
+func (c *mockConn) Unbind() {
+	c.Bind(0)
+}
+
+func (c *mockConn) Send(msg []byte) error {
+	return c.Push(msg)
+}
+
+func (c *mockConn) Push(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pushed = append(c.pushed, msg)
+
+	return nil
+}
+
+func (c *mockConn) State() network.ConnState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return network.ConnClosed
+	}
+
+	return network.ConnOpened
+}
+
+func (c *mockConn) Close(force ...bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	return nil
+}
+
+func (c *mockConn) LocalIP() (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (c *mockConn) LocalAddr() (net.Addr, error) {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil
+}
+
+func (c *mockConn) RemoteIP() (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (c *mockConn) RemoteAddr() (net.Addr, error) {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil
+}
+
+func (c *mockConn) QueueDepth() int {
+	return 0
+}
+
+func (c *mockConn) messages() [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([][]byte(nil), c.pushed...)
+}
+
+// 统计解包次数的打包器
+type countingPacker struct {
+	packet.Packer
+	extractor packet.RouteExtractor
+	unpacks   atomic.Int32
+}
+
+func newCountingPacker() *countingPacker {
+	p := packet.NewPacker()
+
+	return &countingPacker{Packer: p, extractor: p}
+}
+
+func (p *countingPacker) UnpackMessage(data []byte) (*packet.Message, error) {
+	p.unpacks.Add(1)
+
+	return p.Packer.UnpackMessage(data)
+}
+
+func (p *countingPacker) ExtractRoute(data []byte) (int32, error) {
+	return p.extractor.ExtractRoute(data)
+}
+
+func (p *countingPacker) ExtractSeqRoute(data []byte) (int32, int32, error) {
+	return p.extractor.ExtractSeqRoute(data)
+}
+
+func TestHandleReceive_UnpackOnce(t *testing.T) {
+	g := NewGate(WithResumption(time.Minute, 100), WithReliableDelivery(8, 101))
+	defer g.cancel()
+
+	data, err := packet.PackMessage(&packet.Message{Seq: 1, Route: 5, Buffer: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packer := newCountingPacker()
+	packet.SetPacker(packer)
+	defer packet.SetPacker(packet.NewPacker())
+
+	conn := newMockConn(1)
+	g.session.AddConn(conn)
+
+	// 会话恢复与可靠消息确认仅检测路由号，数据包只在投递给节点时解包一次
+	g.handleReceive(conn, data)
+
+	if n := packer.unpacks.Load(); n != 1 {
+		t.Fatalf("unpack count mismatch, want: 1 got: %d", n)
+	}
+}
@@ -0,0 +1,56 @@
+package gate
+
+import (
+	"testing"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/core/limiter"
+	"github.com/dobyte/due/v2/packet"
+)
+
+func TestConnLimiter(t *testing.T) {
+	g := NewGate(WithConnLimiter(1, 0.001))
+	defer g.cancel()
+
+	conn := newMockConn(1)
+	g.session.AddConn(conn)
+
+	l := limiter.NewLimiter(g.opts.capacity, g.opts.rate)
+	g.limiters.Store(conn.ID(), l)
+
+	// 耗尽令牌桶，后续消息将在投递到节点前被拒绝
+	if !l.Allow() {
+		t.Fatal("limiter should allow the first message")
+	}
+
+	data, err := packet.PackMessage(&packet.Message{Seq: 3, Route: 5, Buffer: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g.handleReceive(conn, data)
+
+	messages := conn.messages()
+	if len(messages) != 1 {
+		t.Fatalf("limited message should be replied, replies: %d", len(messages))
+	}
+
+	msg, err := packet.UnpackMessage(messages[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Seq != 3 || msg.Route != 5 {
+		t.Fatalf("reply mismatch, seq: %d route: %d", msg.Seq, msg.Route)
+	}
+
+	reply, err := cluster.UnmarshalCodeReply(g.opts.codec, msg.Buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.Code != codes.TooManyRequests.Code() {
+		t.Fatalf("code mismatch, want: %d got: %d", codes.TooManyRequests.Code(), reply.Code)
+	}
+}
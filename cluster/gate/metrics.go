@@ -0,0 +1,26 @@
+package gate
+
+import (
+	"strconv"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/metrics"
+)
+
+const unknownRoute = "unknown" // 未注册路由的统一标签，避免客户端通过任意路由号制造无限的时间序列
+
+var (
+	receivedMessages = metrics.NewCounter("due_gate_received_messages_total", "Total number of messages received from clients by the gate.")
+	receivedBytes    = metrics.NewCounter("due_gate_received_bytes_total", "Total number of bytes received from clients by the gate.")
+	limitedMessages  = metrics.NewCounter("due_gate_limited_messages_total", "Total number of client messages discarded by the gate rate limiter.")
+	deliverFailures  = metrics.NewCounter("due_gate_deliver_failures_total", "Total number of client messages that failed to be delivered to nodes.", "route")
+)
+
+// 统计投递失败的消息，未注册的路由统一使用unknown标签
+func observeDeliverFailure(route int32, err error) {
+	if errors.Is(err, errors.ErrNotFoundRoute) {
+		deliverFailures.Add(1, unknownRoute)
+	} else {
+		deliverFailures.Add(1, strconv.Itoa(int(route)))
+	}
+}
@@ -0,0 +1,41 @@
+package gate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/metrics"
+)
+
+func TestObserveDeliverFailure(t *testing.T) {
+	registry := metrics.NewRegistry()
+	metrics.SetRegistry(registry)
+	defer metrics.SetRegistry(metrics.NewRegistry())
+
+	// 未注册的路由统一使用unknown标签
+	for route := int32(1000); route < 1010; route++ {
+		observeDeliverFailure(route, errors.ErrNotFoundRoute)
+	}
+
+	observeDeliverFailure(1, errors.ErrNotFoundEndpoint)
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	text := recorder.Body.String()
+
+	for _, line := range []string{
+		`due_gate_deliver_failures_total{route="unknown"} 10`,
+		`due_gate_deliver_failures_total{route="1"} 1`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Fatalf("missing line %q in:\n%s", line, text)
+		}
+	}
+
+	if strings.Contains(text, `route="1000"`) {
+		t.Fatalf("unregistered route should not be labelled:\n%s", text)
+	}
+}
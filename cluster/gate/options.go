@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/crypto"
+	"github.com/dobyte/due/v2/encoding"
 	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/locate"
 	"github.com/dobyte/due/v2/log"
@@ -24,6 +26,7 @@ import (
 const (
 	defaultName     = "gate"          // 默认名称
 	defaultAddr     = ":0"            // 连接器监听地址
+	defaultCodec    = "proto"         // 默认编解码器名称
 	defaultTimeout  = 3 * time.Second // 默认超时时间
 	defaultDispatch = cluster.Random  // 默认的无状态路由分发策略
 )
@@ -33,6 +36,7 @@ const (
 	defaultNameKey     = "etc.cluster.gate.name"
 	defaultAddrKey     = "etc.cluster.gate.addr"
 	defaultExposeKey   = "etc.cluster.gate.expose"
+	defaultCodecKey    = "etc.cluster.gate.codec"
 	defaultTimeoutKey  = "etc.cluster.gate.timeout"
 	defaultDispatchKey = "etc.cluster.gate.dispatch"
 	defaultMetadataKey = "etc.cluster.gate.metadata"
@@ -41,17 +45,31 @@ const (
 type Option func(o *options)
 
 type options struct {
-	ctx      context.Context   // 上下文
-	id       string            // 实例ID
-	name     string            // 实例名称
-	addr     string            // 监听地址
-	expose   bool              // 是否将内部通信地址暴露到公网
-	timeout  time.Duration     // RPC调用超时时间
-	server   network.Server    // 网关服务器
-	locator  locate.Locator    // 用户定位器
-	registry registry.Registry // 服务注册器
-	dispatch cluster.Dispatch  // 无状态路由消息分发策略
-	metadata map[string]string // 元数据
+	ctx       context.Context   // 上下文
+	id        string            // 实例ID
+	name      string            // 实例名称
+	addr      string            // 监听地址
+	expose    bool              // 是否将内部通信地址暴露到公网
+	timeout   time.Duration     // RPC调用超时时间
+	server    network.Server    // 网关服务器
+	locator   locate.Locator    // 用户定位器
+	registry  registry.Registry // 服务注册器
+	dispatch  cluster.Dispatch  // 无状态路由消息分发策略
+	metadata  map[string]string // 元数据
+	codec     encoding.Codec    // 编解码器，用于编码网关回复给客户端的消息
+	encryptor crypto.Encryptor  // 消息加密器，用于加密网关回复给客户端的消息
+	capacity  float64           // 连接限流令牌桶容量
+	rate      float64           // 连接限流令牌桶每秒补充的令牌数
+
+	authenticator     Authenticator     // 连接鉴权器
+	authFailedHandler AuthFailedHandler // 鉴权失败处理器
+	codeReplyHandler  CodeReplyHandler  // 错误码回复处理器
+	resumeGrace       time.Duration     // 会话恢复宽限期
+	resumeRoute       int32             // 会话恢复路由
+	reliableCapacity  int               // 可靠投递缓冲区容量
+	reliableRoute     int32             // 可靠投递路由
+	batchWindow       time.Duration     // 批量推送的刷新窗口
+	batchBytes        int               // 批量推送的最大字节数
 }
 
 func defaultOptions() *options {
@@ -59,6 +77,7 @@ func defaultOptions() *options {
 		ctx:      context.Background(),
 		name:     defaultName,
 		addr:     defaultAddr,
+		codec:    encoding.Invoke(defaultCodec),
 		timeout:  defaultTimeout,
 		dispatch: defaultDispatch,
 		metadata: make(map[string]string),
@@ -79,6 +98,10 @@ func defaultOptions() *options {
 		opts.addr = addr
 	}
 
+	if codec := etc.Get(defaultCodecKey).String(); codec != "" {
+		opts.codec = encoding.Invoke(codec)
+	}
+
 	if timeout := etc.Get(defaultTimeoutKey).Duration(); timeout > 0 {
 		opts.timeout = timeout
 	}
@@ -124,6 +147,16 @@ func WithServer(server network.Server) Option {
 	return func(o *options) { o.server = server }
 }
 
+// WithCodec 设置编解码器，网关回复给客户端的错误码等消息将通过此编解码器进行编码，需与节点的编解码器保持一致
+func WithCodec(codec encoding.Codec) Option {
+	return func(o *options) { o.codec = codec }
+}
+
+// WithEncryptor 设置消息加密器，网关回复给客户端的错误码等消息将通过此加密器进行加密，需与节点的加密器保持一致
+func WithEncryptor(encryptor crypto.Encryptor) Option {
+	return func(o *options) { o.encryptor = encryptor }
+}
+
 // WithTimeout 设置RPC调用超时时间
 func WithTimeout(timeout time.Duration) Option {
 	return func(o *options) { o.timeout = timeout }
@@ -148,3 +181,48 @@ func WithDispatch(dispatch cluster.Dispatch) Option {
 func WithMetadata(metadata map[string]string) Option {
 	return func(o *options) { maps.Copy(o.metadata, metadata) }
 }
+
+// WithConnLimiter 设置连接级限流
+// 每个连接独立拥有一个容量为capacity、每秒补充rate个令牌的令牌桶，超出限制的消息将在投递到节点前被丢弃，并向客户端回复codes.TooManyRequests
+func WithConnLimiter(capacity, rate float64) Option {
+	return func(o *options) { o.capacity, o.rate = capacity, rate }
+}
+
+// WithAuthenticator 设置连接鉴权器
+// 设置鉴权器后，连接收到的第一个数据包将用于鉴权，鉴权通过前不会向节点投递任何消息与事件
+// 可配合网络服务器的authorizeTimeout选项关闭长时间未完成鉴权的连接
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(o *options) { o.authenticator = authenticator }
+}
+
+// WithAuthFailedHandler 设置鉴权失败处理器，未设置时使用错误码回复处理器
+func WithAuthFailedHandler(handler AuthFailedHandler) Option {
+	return func(o *options) { o.authFailedHandler = handler }
+}
+
+// WithCodeReplyHandler 设置错误码回复处理器
+// 未设置时通过网关的编解码器与加密器回复cluster.CodeReply消息，与节点的默认回复格式一致
+func WithCodeReplyHandler(handler CodeReplyHandler) Option {
+	return func(o *options) { o.codeReplyHandler = handler }
+}
+
+// WithResumption 设置会话恢复
+// 开启后网关会在用户绑定时通过route路由向客户端推送恢复令牌，连接断开后在grace宽限期内保留用户的绑定关系与频道订阅
+// 客户端重连后以route路由发送恢复令牌作为第一个数据包即可接管原会话，网关将向节点触发cluster.Reconnect事件替代断开与连接事件
+func WithResumption(grace time.Duration, route int32) Option {
+	return func(o *options) { o.resumeGrace, o.resumeRoute = grace, route }
+}
+
+// WithReliableDelivery 设置可靠投递
+// 开启后网关会为每个用户保留最近capacity条推送消息，消息以route路由携带发件箱纪元与序列号推送给客户端，客户端以route路由回复纪元与已接收的最大序列号进行确认
+// 用户恢复会话后，网关将重放所有未被确认的消息；可靠投递依赖会话恢复，仅对以用户为目标的推送生效
+func WithReliableDelivery(capacity int, route int32) Option {
+	return func(o *options) { o.reliableCapacity, o.reliableRoute = capacity, route }
+}
+
+// WithPushBatching 设置批量推送
+// 开启后推送给同一连接的消息将在window刷新窗口内合并为一个批量数据包写入连接，待推送的消息达到maxBytes字节时立即刷新
+// maxBytes小于等于0时默认为4096字节；客户端需支持解包批量数据包
+func WithPushBatching(window time.Duration, maxBytes int) Option {
+	return func(o *options) { o.batchWindow, o.batchBytes = window, maxBytes }
+}
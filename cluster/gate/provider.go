@@ -28,9 +28,14 @@ func (p *provider) Bind(ctx context.Context, cid, uid int64) error {
 	err = p.gate.proxy.bindGate(ctx, cid, uid)
 	if err != nil {
 		_, _ = p.gate.session.Unbind(uid)
+		return err
 	}
 
-	return err
+	p.gate.openOutbox(uid)
+
+	p.gate.issueResumeToken(cid, uid)
+
+	return nil
 }
 
 // Unbind 解绑用户与网关间的关系
@@ -44,6 +49,8 @@ func (p *provider) Unbind(ctx context.Context, uid int64) error {
 		return err
 	}
 
+	p.gate.revokeResumeToken(uid)
+
 	return p.gate.proxy.unbindGate(ctx, cid, uid)
 }
 
@@ -62,6 +69,16 @@ func (p *provider) Stat(ctx context.Context, kind session.Kind) (int64, error) {
 	return p.gate.session.Stat(kind)
 }
 
+// StatChannel 统计频道订阅者数量
+func (p *provider) StatChannel(ctx context.Context, channel string) (int64, error) {
+	return p.gate.session.StatChannel(channel), nil
+}
+
+// GetChannels 获取订阅的频道
+func (p *provider) GetChannels(ctx context.Context, kind session.Kind, target int64) ([]string, error) {
+	return p.gate.session.Channels(kind, target)
+}
+
 // Disconnect 断开连接
 func (p *provider) Disconnect(ctx context.Context, kind session.Kind, target int64, force bool) error {
 	return p.gate.session.Close(kind, target, force)
@@ -69,9 +86,21 @@ func (p *provider) Disconnect(ctx context.Context, kind session.Kind, target int
 
 // Push 发送消息
 func (p *provider) Push(ctx context.Context, kind session.Kind, target int64, message []byte) error {
+	if kind == session.User && p.gate.isReliable() {
+		if ok, err := p.gate.pushReliable(target, message); ok {
+			return err
+		}
+	}
+
 	err := p.gate.session.Push(kind, target, message)
 
 	if kind == session.User && errors.Is(err, errors.ErrNotFoundSession) {
+		// 用户会话处于宽限期时保留绑定关系，丢弃推送消息，以便用户恢复会话后仍能路由到当前网关
+		if p.gate.isSuspended(target) {
+			log.Debugf("push message dropped, user session is suspended, uid = %d", target)
+			return err
+		}
+
 		xcall.Go(func() {
 			if e := p.gate.opts.locator.UnbindGate(ctx, target, p.gate.opts.id); e != nil {
 				log.Errorf("unbind gate failed, uid = %d gid = %s err = %v", target, p.gate.opts.id, e)
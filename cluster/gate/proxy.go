@@ -9,6 +9,7 @@ import (
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/mode"
 	"github.com/dobyte/due/v2/packet"
+	"github.com/dobyte/due/v2/trace"
 )
 
 type proxy struct {
@@ -54,11 +55,19 @@ func (p *proxy) trigger(ctx context.Context, event cluster.Event, cid, uid int64
 		log.Debugf("trigger event, event: %v cid: %d uid: %d", event.String(), cid, uid)
 	}
 
+	ctx, span := trace.Start(ctx, "gate.trigger")
+	span.SetAttribute("event", event.String())
+	span.SetAttribute("cid", cid)
+	span.SetAttribute("uid", uid)
+	defer span.Finish()
+
 	if err := p.nodeLinker.Trigger(ctx, &link.TriggerArgs{
 		Event: event,
 		CID:   cid,
 		UID:   uid,
 	}); err != nil {
+		span.SetError(err)
+
 		switch {
 		case errors.Is(err, errors.ErrNotFoundEvent), errors.Is(err, errors.ErrNotFoundUserLocation):
 			log.Warnf("trigger event failed, cid: %d, uid: %d, event: %v, err: %v", cid, uid, event.String(), err)
@@ -80,12 +89,22 @@ func (p *proxy) deliver(ctx context.Context, cid, uid int64, message []byte) {
 		log.Debugf("deliver message, cid: %d uid: %d seq: %d route: %d buffer: %s", cid, uid, msg.Seq, msg.Route, string(msg.Buffer))
 	}
 
+	ctx, span := trace.Start(ctx, "gate.deliver")
+	span.SetAttribute("route", msg.Route)
+	span.SetAttribute("seq", msg.Seq)
+	span.SetAttribute("cid", cid)
+	span.SetAttribute("uid", uid)
+	defer span.Finish()
+
 	if err = p.nodeLinker.Deliver(ctx, &link.DeliverArgs{
 		CID:     cid,
 		UID:     uid,
 		Route:   msg.Route,
 		Message: message,
 	}); err != nil {
+		observeDeliverFailure(msg.Route, err)
+		span.SetError(err)
+
 		switch {
 		case errors.Is(err, errors.ErrNotFoundRoute), errors.Is(err, errors.ErrNotFoundEndpoint):
 			log.Warnf("deliver message failed, cid: %d uid: %d seq: %d route: %d err: %v", cid, uid, msg.Seq, msg.Route, err)
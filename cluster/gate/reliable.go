@@ -0,0 +1,208 @@
+package gate
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+	"github.com/dobyte/due/v2/session"
+)
+
+// reliable message
+// ------------------------------------------------------
+// | epoch(4 byte) | seq(4 byte) | message packet(n byte) |
+// ------------------------------------------------------
+// reliable ack
+// ------------------------------------------------------
+// | epoch(4 byte) | ack seq(4 byte)                      |
+// ------------------------------------------------------
+// 每次开启新的发件箱时都会生成新的纪元，序列号从1重新开始
+// 客户端收到新纪元的消息时需重置已接收的序列号，网关会忽略非当前纪元的确认
+
+const (
+	reliableEpochBytes  = 4
+	reliableSeqBytes    = 4
+	reliableHeaderBytes = reliableEpochBytes + reliableSeqBytes
+)
+
+type outboxEntry struct {
+	seq  uint32 // 序列号
+	data []byte // 可靠消息数据包
+}
+
+type outbox struct {
+	mu    sync.Mutex
+	epoch uint32        // 发件箱纪元
+	seq   uint32        // 已推送的最大序列号
+	acked uint32        // 客户端已确认的最大序列号
+	ring  []outboxEntry // 环形缓冲区
+}
+
+type reliability struct {
+	rw       sync.RWMutex
+	outboxes map[int64]*outbox // 用户发件箱（用户ID -> 发件箱）
+}
+
+func newReliability() *reliability {
+	return &reliability{outboxes: make(map[int64]*outbox)}
+}
+
+// 是否开启可靠投递
+// 可靠投递依赖会话恢复，未开启会话恢复时不生效
+func (g *Gate) isReliable() bool {
+	return g.opts.reliableCapacity > 0 && g.isResumable()
+}
+
+// 为用户开启新的发件箱
+func (g *Gate) openOutbox(uid int64) {
+	if !g.isReliable() {
+		return
+	}
+
+	g.reliability.rw.Lock()
+	g.reliability.outboxes[uid] = &outbox{epoch: newOutboxEpoch(), ring: make([]outboxEntry, g.opts.reliableCapacity)}
+	g.reliability.rw.Unlock()
+}
+
+// 生成发件箱纪元
+func newOutboxEpoch() uint32 {
+	for {
+		if epoch := rand.Uint32(); epoch != 0 {
+			return epoch
+		}
+	}
+}
+
+// 关闭用户的发件箱
+func (g *Gate) closeOutbox(uid int64) {
+	if !g.isReliable() {
+		return
+	}
+
+	g.reliability.rw.Lock()
+	delete(g.reliability.outboxes, uid)
+	g.reliability.rw.Unlock()
+}
+
+// 加载用户的发件箱
+func (g *Gate) loadOutbox(uid int64) (*outbox, bool) {
+	g.reliability.rw.RLock()
+	defer g.reliability.rw.RUnlock()
+
+	ob, ok := g.reliability.outboxes[uid]
+
+	return ob, ok
+}
+
+// 可靠地推送消息给用户
+// 消息将被分配序列号并写入发件箱，用户处于会话恢复宽限期时，消息将在用户恢复会话后重放
+func (g *Gate) pushReliable(uid int64, message []byte) (bool, error) {
+	ob, ok := g.loadOutbox(uid)
+	if !ok {
+		return false, nil
+	}
+
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	seq := ob.seq + 1
+
+	buf := make([]byte, reliableHeaderBytes+len(message))
+	binary.BigEndian.PutUint32(buf, ob.epoch)
+	binary.BigEndian.PutUint32(buf[reliableEpochBytes:], seq)
+	copy(buf[reliableHeaderBytes:], message)
+
+	data, err := packet.PackMessage(&packet.Message{
+		Route:  g.opts.reliableRoute,
+		Buffer: buf,
+	})
+	if err != nil {
+		return true, err
+	}
+
+	idx := int(seq % uint32(len(ob.ring)))
+
+	if old := ob.ring[idx]; old.seq > ob.acked {
+		log.Warnf("reliable outbox overflowed, unacknowledged message dropped, uid: %d seq: %d acked: %d", uid, old.seq, ob.acked)
+	}
+
+	ob.seq = seq
+	ob.ring[idx] = outboxEntry{seq: seq, data: data}
+
+	if err = g.session.Push(session.User, uid, data); err != nil && !errors.Is(err, errors.ErrNotFoundSession) {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// 在会话中重新绑定用户并重放客户端未确认的消息
+// 重放期间发件箱被锁定，新的消息将在重放完成后推送，以保证消息顺序
+func (g *Gate) rebind(cid, uid int64) error {
+	ob, ok := g.loadOutbox(uid)
+	if !ok {
+		return g.session.Bind(cid, uid)
+	}
+
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if err := g.session.Bind(cid, uid); err != nil {
+		return err
+	}
+
+	start := ob.acked + 1
+	if n := uint32(len(ob.ring)); ob.seq > n && start <= ob.seq-n {
+		start = ob.seq - n + 1
+	}
+
+	for seq := start; seq <= ob.seq && seq != 0; seq++ {
+		entry := ob.ring[int(seq%uint32(len(ob.ring)))]
+		if entry.seq != seq {
+			continue
+		}
+
+		if err := g.session.Push(session.Conn, cid, entry.data); err != nil {
+			log.Warnf("replay reliable message failed, cid: %d uid: %d seq: %d err: %v", cid, uid, seq, err)
+			break
+		}
+	}
+
+	return nil
+}
+
+// 处理客户端的可靠消息确认，返回true表示数据包为确认包
+func (g *Gate) acknowledge(conn network.Conn, data []byte) bool {
+	if !g.isReliable() {
+		return false
+	}
+
+	if route, err := packet.ExtractRoute(data); err != nil || route != g.opts.reliableRoute {
+		return false
+	}
+
+	msg, err := packet.UnpackMessage(data)
+	if err != nil || len(msg.Buffer) < reliableHeaderBytes {
+		return true
+	}
+
+	ob, ok := g.loadOutbox(conn.UID())
+	if !ok {
+		return true
+	}
+
+	epoch := binary.BigEndian.Uint32(msg.Buffer)
+	seq := binary.BigEndian.Uint32(msg.Buffer[reliableEpochBytes:])
+
+	ob.mu.Lock()
+	if epoch == ob.epoch && seq > ob.acked && seq <= ob.seq {
+		ob.acked = seq
+	}
+	ob.mu.Unlock()
+
+	return true
+}
@@ -0,0 +1,100 @@
+package gate
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/packet"
+)
+
+const testReliableRoute = 100
+
+func unpackReliable(t *testing.T, data []byte) (uint32, uint32) {
+	t.Helper()
+
+	msg, err := packet.UnpackMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Route != testReliableRoute || len(msg.Buffer) < reliableHeaderBytes {
+		t.Fatalf("invalid reliable message: %+v", msg)
+	}
+
+	return binary.BigEndian.Uint32(msg.Buffer), binary.BigEndian.Uint32(msg.Buffer[reliableEpochBytes:])
+}
+
+func packAck(t *testing.T, epoch, seq uint32) []byte {
+	t.Helper()
+
+	buf := make([]byte, reliableHeaderBytes)
+	binary.BigEndian.PutUint32(buf, epoch)
+	binary.BigEndian.PutUint32(buf[reliableEpochBytes:], seq)
+
+	data, err := packet.PackMessage(&packet.Message{Route: testReliableRoute, Buffer: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestReliableOutbox(t *testing.T) {
+	const uid = int64(1)
+
+	g := NewGate(WithResumption(time.Minute, 10), WithReliableDelivery(2, testReliableRoute))
+	defer g.cancel()
+
+	conn := newMockConn(1)
+	g.session.AddConn(conn)
+
+	if err := g.session.Bind(conn.ID(), uid); err != nil {
+		t.Fatal(err)
+	}
+
+	g.openOutbox(uid)
+
+	for i := 0; i < 3; i++ {
+		if ok, err := g.pushReliable(uid, []byte("hello")); !ok || err != nil {
+			t.Fatalf("push reliable message failed, ok: %v err: %v", ok, err)
+		}
+	}
+
+	messages := conn.messages()
+	if len(messages) != 3 {
+		t.Fatalf("pushed count mismatch, want: 3 got: %d", len(messages))
+	}
+
+	epoch, _ := unpackReliable(t, messages[0])
+	for i, message := range messages {
+		if e, seq := unpackReliable(t, message); e != epoch || seq != uint32(i+1) {
+			t.Fatalf("unexpected reliable message, epoch: %d seq: %d", e, seq)
+		}
+	}
+
+	ob, _ := g.loadOutbox(uid)
+
+	// 非当前纪元的确认将被忽略
+	g.acknowledge(conn, packAck(t, epoch+1, 3))
+	if ob.acked != 0 {
+		t.Fatalf("ack of stale epoch is applied, acked: %d", ob.acked)
+	}
+
+	g.acknowledge(conn, packAck(t, epoch, 2))
+	if ob.acked != 2 {
+		t.Fatalf("acked mismatch, want: 2 got: %d", ob.acked)
+	}
+
+	// 重新开启发件箱后，序列号从1重新开始且纪元发生变化
+	g.openOutbox(uid)
+
+	if ok, err := g.pushReliable(uid, []byte("hello")); !ok || err != nil {
+		t.Fatalf("push reliable message failed, ok: %v err: %v", ok, err)
+	}
+
+	messages = conn.messages()
+	if e, seq := unpackReliable(t, messages[len(messages)-1]); e == epoch || seq != 1 {
+		t.Fatalf("unexpected reliable message of new outbox, epoch: %d seq: %d", e, seq)
+	}
+}
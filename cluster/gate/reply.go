@@ -0,0 +1,103 @@
+package gate
+
+import (
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+)
+
+// CodeReplyHandler 错误码回复处理器
+// 消息因鉴权失败、限流等原因在网关被拒绝时，通过此处理器将错误码回复给客户端
+type CodeReplyHandler func(ctx *ReplyContext, code *codes.Code) error
+
+// ReplyContext 错误码回复上下文
+type ReplyContext struct {
+	gate *Gate
+	conn network.Conn
+	data []byte
+}
+
+// Conn 获取被拒绝的连接
+func (c *ReplyContext) Conn() network.Conn {
+	return c.conn
+}
+
+// Data 获取被拒绝的原始数据包
+func (c *ReplyContext) Data() []byte {
+	return c.data
+}
+
+// Response 使用原始数据包的序列号与路由号回复消息
+// 消息为[]byte时直接回复，否则通过网关的编解码器与加密器进行编码与加密
+func (c *ReplyContext) Response(message any) error {
+	buf, ok := message.([]byte)
+	if !ok {
+		var err error
+
+		if buf, err = c.gate.opts.codec.Marshal(message); err != nil {
+			return err
+		}
+
+		if buf, err = c.gate.encrypt(buf); err != nil {
+			return err
+		}
+	}
+
+	seq, route, err := packet.ExtractSeqRoute(c.data)
+	if err != nil {
+		return err
+	}
+
+	msg, err := packet.PackMessage(&packet.Message{
+		Seq:    seq,
+		Route:  route,
+		Buffer: buf,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.conn.Send(msg)
+}
+
+// 默认的错误码回复处理器
+// 通过网关的编解码器与加密器回复cluster.CodeReply消息，与节点的默认回复格式一致
+func (g *Gate) defaultCodeReplyHandler(ctx *ReplyContext, code *codes.Code) error {
+	buf, err := cluster.MarshalCodeReply(g.opts.codec, code)
+	if err != nil {
+		return err
+	}
+
+	if buf, err = g.encrypt(buf); err != nil {
+		return err
+	}
+
+	return ctx.Response(buf)
+}
+
+// 加密回复消息
+func (g *Gate) encrypt(buf []byte) ([]byte, error) {
+	if g.opts.encryptor == nil {
+		return buf, nil
+	}
+
+	return g.opts.encryptor.Encrypt(buf)
+}
+
+// 回复错误码
+func (g *Gate) replyCode(conn network.Conn, data []byte, code *codes.Code) {
+	g.doReplyCode(g.opts.codeReplyHandler, conn, data, code)
+}
+
+// 使用指定的处理器回复错误码，处理器为空时使用默认的错误码回复处理器
+func (g *Gate) doReplyCode(handler CodeReplyHandler, conn network.Conn, data []byte, code *codes.Code) {
+	if handler == nil {
+		handler = g.defaultCodeReplyHandler
+	}
+
+	if err := handler(&ReplyContext{gate: g, conn: conn, data: data}, code); err != nil {
+		log.Warnf("reply code failed, cid: %d uid: %d code: %d err: %v", conn.ID(), conn.UID(), code.Code(), err)
+	}
+}
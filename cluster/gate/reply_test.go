@@ -0,0 +1,113 @@
+package gate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/packet"
+)
+
+type xorEncryptor struct{}
+
+func (xorEncryptor) Name() string { return "xor" }
+
+func (xorEncryptor) Encrypt(data []byte) ([]byte, error) {
+	buf := bytes.Clone(data)
+	for i := range buf {
+		buf[i] ^= 0xff
+	}
+
+	return buf, nil
+}
+
+func (e xorEncryptor) Decrypt(data []byte) ([]byte, error) {
+	return e.Encrypt(data)
+}
+
+// 回复错误码并解析回复消息
+func replyCode(t *testing.T, g *Gate, code *codes.Code) (*packet.Message, *cluster.CodeReply) {
+	t.Helper()
+
+	conn := newMockConn(1)
+
+	data, err := packet.PackMessage(&packet.Message{Seq: 7, Route: 9, Buffer: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g.replyCode(conn, data, code)
+
+	messages := conn.messages()
+	if len(messages) != 1 {
+		t.Fatalf("code is not replied, replies: %d", len(messages))
+	}
+
+	msg, err := packet.UnpackMessage(messages[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Seq != 7 || msg.Route != 9 {
+		t.Fatalf("reply mismatch, seq: %d route: %d", msg.Seq, msg.Route)
+	}
+
+	buf := msg.Buffer
+	if g.opts.encryptor != nil {
+		if buf, err = g.opts.encryptor.Decrypt(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reply, err := cluster.UnmarshalCodeReply(g.opts.codec, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return msg, reply
+}
+
+func TestReplyCode(t *testing.T) {
+	for _, g := range []*Gate{
+		NewGate(),
+		NewGate(WithCodec(json.DefaultCodec)),
+		NewGate(WithEncryptor(xorEncryptor{})),
+	} {
+		msg, reply := replyCode(t, g, codes.Unauthorized)
+
+		if reply.Code != codes.Unauthorized.Code() || reply.Message != codes.Unauthorized.Message() {
+			t.Fatalf("%s code reply mismatch, got: %+v", g.opts.codec.Name(), reply)
+		}
+
+		// 与节点的默认回复格式保持一致
+		want, err := cluster.MarshalCodeReply(g.opts.codec, codes.Unauthorized)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want, err = g.encrypt(want); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(msg.Buffer, want) {
+			t.Fatalf("%s code reply payload mismatch", g.opts.codec.Name())
+		}
+
+		g.cancel()
+	}
+}
+
+func TestReplyCode_Handler(t *testing.T) {
+	g := NewGate(WithCodec(json.DefaultCodec), WithCodeReplyHandler(func(ctx *ReplyContext, code *codes.Code) error {
+		return ctx.Response(&cluster.CodeReply{Code: code.Code(), Message: "custom"})
+	}))
+	defer g.cancel()
+
+	_, reply := replyCode(t, g, codes.TooManyRequests)
+
+	if reply.Code != codes.TooManyRequests.Code() || reply.Message != "custom" {
+		t.Fatalf("custom code reply mismatch, got: %+v", reply)
+	}
+}
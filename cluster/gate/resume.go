@@ -0,0 +1,251 @@
+package gate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+	"github.com/dobyte/due/v2/session"
+)
+
+type resumeEntry struct {
+	cid      int64       // 断开前的连接ID
+	uid      int64       // 用户ID
+	token    string      // 恢复令牌
+	channels []string    // 断开前订阅的频道
+	timer    *time.Timer // 宽限期定时器
+}
+
+type resumption struct {
+	mu       sync.Mutex
+	tokens   map[int64]string        // 用户已签发的恢复令牌（用户ID -> 恢复令牌）
+	suspends map[string]*resumeEntry // 处于宽限期的会话（恢复令牌 -> 会话）
+}
+
+func newResumption() *resumption {
+	return &resumption{
+		tokens:   make(map[int64]string),
+		suspends: make(map[string]*resumeEntry),
+	}
+}
+
+// 是否开启会话恢复
+func (g *Gate) isResumable() bool {
+	return g.opts.resumeGrace > 0
+}
+
+// 签发恢复令牌并推送给客户端
+// 用户重新绑定时，该用户处于宽限期的旧会话将被丢弃
+func (g *Gate) issueResumeToken(cid, uid int64) {
+	if !g.isResumable() {
+		return
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		log.Errorf("generate resume token failed, cid: %d uid: %d err: %v", cid, uid, err)
+		return
+	}
+
+	token := hex.EncodeToString(buf)
+
+	g.resumption.mu.Lock()
+	if old, ok := g.resumption.tokens[uid]; ok {
+		if entry, ok := g.resumption.suspends[old]; ok {
+			entry.timer.Stop()
+			delete(g.resumption.suspends, old)
+		}
+	}
+	g.resumption.tokens[uid] = token
+	g.resumption.mu.Unlock()
+
+	msg, err := packet.PackMessage(&packet.Message{
+		Route:  g.opts.resumeRoute,
+		Buffer: []byte(token),
+	})
+	if err != nil {
+		log.Errorf("pack resume token failed, cid: %d uid: %d err: %v", cid, uid, err)
+		return
+	}
+
+	if err = g.session.Push(session.Conn, cid, msg); err != nil {
+		log.Warnf("push resume token failed, cid: %d uid: %d err: %v", cid, uid, err)
+	}
+}
+
+// 吊销用户的恢复令牌
+func (g *Gate) revokeResumeToken(uid int64) {
+	if !g.isResumable() {
+		return
+	}
+
+	g.resumption.mu.Lock()
+	defer g.resumption.mu.Unlock()
+
+	if token, ok := g.resumption.tokens[uid]; ok {
+		if entry, ok := g.resumption.suspends[token]; ok {
+			entry.timer.Stop()
+			delete(g.resumption.suspends, token)
+		}
+		delete(g.resumption.tokens, uid)
+	}
+
+	g.closeOutbox(uid)
+}
+
+// 挂起断开连接的用户会话，宽限期内用户的绑定关系与频道订阅将被保留
+// 需在会话移除连接前调用，返回false表示未挂起
+func (g *Gate) suspend(conn network.Conn) bool {
+	if !g.isResumable() {
+		return false
+	}
+
+	cid, uid := conn.ID(), conn.UID()
+	if uid == 0 {
+		return false
+	}
+
+	g.resumption.mu.Lock()
+	defer g.resumption.mu.Unlock()
+
+	token, ok := g.resumption.tokens[uid]
+	if !ok {
+		return false
+	}
+
+	entry := &resumeEntry{cid: cid, uid: uid, token: token}
+
+	conn.Attr().Visit(func(channel, _ any) bool {
+		entry.channels = append(entry.channels, channel.(string))
+		return true
+	})
+
+	entry.timer = time.AfterFunc(g.opts.resumeGrace, func() { g.expire(token) })
+
+	g.resumption.suspends[token] = entry
+
+	return true
+}
+
+// 检测用户会话是否处于宽限期
+func (g *Gate) isSuspended(uid int64) bool {
+	if !g.isResumable() {
+		return false
+	}
+
+	g.resumption.mu.Lock()
+	defer g.resumption.mu.Unlock()
+
+	token, ok := g.resumption.tokens[uid]
+	if !ok {
+		return false
+	}
+
+	_, ok = g.resumption.suspends[token]
+
+	return ok
+}
+
+// 宽限期结束，解绑用户并向节点触发断开连接事件
+func (g *Gate) expire(token string) {
+	g.resumption.mu.Lock()
+	entry, ok := g.resumption.suspends[token]
+	if ok {
+		delete(g.resumption.suspends, token)
+		delete(g.resumption.tokens, entry.uid)
+	}
+	g.resumption.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	g.closeOutbox(entry.uid)
+
+	g.doExpire(entry)
+}
+
+// 结束挂起的会话
+func (g *Gate) doExpire(entry *resumeEntry) {
+	ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
+	defer cancel()
+
+	_ = g.proxy.unbindGate(ctx, entry.cid, entry.uid)
+	g.proxy.trigger(ctx, cluster.Disconnect, entry.cid, entry.uid)
+}
+
+// 结束所有挂起的会话
+func (g *Gate) expireAll() {
+	if !g.isResumable() {
+		return
+	}
+
+	g.resumption.mu.Lock()
+	entries := make([]*resumeEntry, 0, len(g.resumption.suspends))
+	for token, entry := range g.resumption.suspends {
+		entry.timer.Stop()
+		entries = append(entries, entry)
+		delete(g.resumption.suspends, token)
+		delete(g.resumption.tokens, entry.uid)
+	}
+	g.resumption.mu.Unlock()
+
+	for _, entry := range entries {
+		g.closeOutbox(entry.uid)
+		g.doExpire(entry)
+	}
+}
+
+// 使用恢复令牌接管挂起的会话，并向节点触发重连事件
+func (g *Gate) resume(conn network.Conn, data []byte, token string) {
+	g.resumption.mu.Lock()
+	entry, ok := g.resumption.suspends[token]
+	if ok {
+		entry.timer.Stop()
+		delete(g.resumption.suspends, token)
+	}
+	g.resumption.mu.Unlock()
+
+	if !ok {
+		g.rejectConn(conn, data, codes.Unauthorized, errors.ErrInvalidArgument)
+		return
+	}
+
+	cid, uid := conn.ID(), entry.uid
+
+	ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
+	defer cancel()
+
+	if err := g.rebind(cid, uid); err != nil {
+		g.revokeResumeToken(uid)
+		g.doExpire(entry)
+		g.rejectConn(conn, data, codes.InternalError, err)
+		return
+	}
+
+	if err := g.opts.locator.BindGate(ctx, uid, g.opts.id); err != nil {
+		_, _ = g.session.Unbind(uid)
+		g.revokeResumeToken(uid)
+		g.doExpire(entry)
+		g.rejectConn(conn, data, codes.InternalError, err)
+		return
+	}
+
+	for _, channel := range entry.channels {
+		_ = g.session.Subscribe(session.Conn, []int64{cid}, channel)
+	}
+
+	g.established.Store(cid, struct{}{})
+
+	g.issueResumeToken(cid, uid)
+
+	g.proxy.trigger(ctx, cluster.Reconnect, cid, uid)
+}
@@ -0,0 +1,75 @@
+package gate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/locate/memory"
+	registry "github.com/dobyte/due/v2/registry/memory"
+	"github.com/dobyte/due/v2/session"
+)
+
+func TestPushSuspended(t *testing.T) {
+	const (
+		gid = "gate-1"
+		uid = int64(1)
+	)
+
+	ctx := context.Background()
+	locator := memory.NewLocator()
+
+	g := NewGate(
+		WithID(gid),
+		WithLocator(locator),
+		WithRegistry(registry.NewRegistry()),
+		WithResumption(time.Minute, 10),
+	)
+	defer g.cancel()
+
+	conn := newMockConn(1)
+	g.session.AddConn(conn)
+
+	if err := g.session.Bind(conn.ID(), uid); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := locator.BindGate(ctx, uid, gid); err != nil {
+		t.Fatal(err)
+	}
+
+	g.issueResumeToken(conn.ID(), uid)
+
+	token := g.resumption.tokens[uid]
+	if token == "" {
+		t.Fatal("resume token is not issued")
+	}
+
+	if !g.suspend(conn) {
+		t.Fatal("session is not suspended")
+	}
+	g.session.RemConn(conn)
+
+	if err := (&provider{gate: g}).Push(ctx, session.User, uid, []byte("hello")); err == nil {
+		t.Fatal("push to suspended user should fail")
+	}
+
+	// 等待可能存在的异步解绑
+	time.Sleep(50 * time.Millisecond)
+
+	if id, err := locator.LocateGate(ctx, uid); err != nil || id != gid {
+		t.Fatalf("binding lost during grace period, gid: %q err: %v", id, err)
+	}
+
+	newConn := newMockConn(2)
+	g.session.AddConn(newConn)
+	g.resume(newConn, nil, token)
+
+	if newConn.UID() != uid {
+		t.Fatalf("session is not resumed, uid: %d", newConn.UID())
+	}
+
+	if id, err := locator.LocateGate(ctx, uid); err != nil || id != gid {
+		t.Fatalf("binding lost after resume, gid: %q err: %v", id, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"maps"
 	"time"
 
+	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/crypto"
 	"github.com/dobyte/due/v2/encoding"
 	"github.com/dobyte/due/v2/etc"
@@ -16,9 +17,10 @@ import (
 )
 
 const (
-	defaultName    = "mesh"          // 默认节点名称
-	defaultCodec   = "proto"         // 默认编解码器名称
-	defaultTimeout = 3 * time.Second // 默认超时时间
+	defaultName     = "mesh"          // 默认节点名称
+	defaultCodec    = "proto"         // 默认编解码器名称
+	defaultTimeout  = 3 * time.Second // 默认超时时间
+	defaultDispatch = cluster.Random  // 默认的无状态路由分发策略
 )
 
 const (
@@ -26,6 +28,7 @@ const (
 	defaultNameKey     = "etc.cluster.mesh.name"
 	defaultCodecKey    = "etc.cluster.mesh.codec"
 	defaultTimeoutKey  = "etc.cluster.mesh.timeout"
+	defaultDispatchKey = "etc.cluster.mesh.dispatch"
 	defaultMetadataKey = "etc.cluster.mesh.metadata"
 )
 
@@ -37,6 +40,7 @@ type options struct {
 	ctx         context.Context       // 上下文
 	codec       encoding.Codec        // 编解码器
 	timeout     time.Duration         // RPC调用超时时间
+	dispatch    cluster.Dispatch      // 无状态路由消息分发策略
 	locator     locate.Locator        // 用户定位器
 	registry    registry.Registry     // 服务注册器
 	encryptor   crypto.Encryptor      // 消息加密器
@@ -50,6 +54,7 @@ func defaultOptions() *options {
 		name:     defaultName,
 		codec:    encoding.Invoke(defaultCodec),
 		timeout:  defaultTimeout,
+		dispatch: defaultDispatch,
 		metadata: make(map[string]string),
 	}
 
@@ -71,6 +76,10 @@ func defaultOptions() *options {
 		opts.timeout = timeout
 	}
 
+	if strategy := etc.Get(defaultDispatchKey).String(); strategy != "" {
+		opts.dispatch = cluster.Dispatch(strategy)
+	}
+
 	if err := etc.Get(defaultMetadataKey).Scan(&opts.metadata); err != nil {
 		log.Warnf("scan mesh metadata failed: %v", err)
 	}
@@ -98,6 +107,11 @@ func WithTimeout(timeout time.Duration) Option {
 	return func(o *options) { o.timeout = timeout }
 }
 
+// WithDispatch 设置无状态路由消息分发策略
+func WithDispatch(dispatch cluster.Dispatch) Option {
+	return func(o *options) { o.dispatch = dispatch }
+}
+
 // WithLocator 设置定位器
 func WithLocator(locator locate.Locator) Option {
 	return func(o *options) { o.locator = locator }
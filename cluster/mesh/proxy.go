@@ -24,6 +24,7 @@ func newProxy(mesh *Mesh) *Proxy {
 		Locator:   mesh.opts.locator,
 		Registry:  mesh.opts.registry,
 		Encryptor: mesh.opts.encryptor,
+		Dispatch:  mesh.opts.dispatch,
 	}
 
 	return &Proxy{
@@ -141,6 +142,16 @@ func (p *Proxy) Stat(ctx context.Context, kind session.Kind) (int64, error) {
 	return p.gateLinker.Stat(ctx, kind)
 }
 
+// StatChannel 统计频道订阅者数量，订阅者包含订阅了匹配该频道的通配符频道的连接
+func (p *Proxy) StatChannel(ctx context.Context, channel string) (int64, error) {
+	return p.gateLinker.StatChannel(ctx, channel)
+}
+
+// GetChannels 获取订阅的频道
+func (p *Proxy) GetChannels(ctx context.Context, args *cluster.GetChannelsArgs) ([]string, error) {
+	return p.gateLinker.GetChannels(ctx, args)
+}
+
 // IsOnline 检测是否在线
 func (p *Proxy) IsOnline(ctx context.Context, args *cluster.IsOnlineArgs) (bool, error) {
 	return p.gateLinker.IsOnline(ctx, args)
@@ -1,12 +1,13 @@
 package node
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/dobyte/due/v2/cluster"
-	"github.com/dobyte/due/v2/utils/xcall"
+	"github.com/dobyte/due/v2/log"
 )
 
 type Creator func(actor *Actor, args ...any) Processor
@@ -21,6 +22,7 @@ type Actor struct {
 	opts                *actorOptions                  // 配置项
 	scheduler           *Scheduler                     // 调度器
 	state               atomic.Int32                   // 状态
+	initializing        atomic.Bool                    // 是否正在初始化处理器
 	routes              map[int32]RouteHandler         // 路由处理器
 	events              map[cluster.Event]EventHandler // 事件处理器
 	defaultRouteHandler RouteHandler                   // 默认路由处理器
@@ -29,6 +31,14 @@ type Actor struct {
 	mailbox             chan Context                   // 邮箱
 	fnChan              chan func()                    // 调用函数
 	binds               sync.Map                       // 绑定的用户
+	creator             Creator                        // 处理器创建器
+	restarts            []time.Time                    // 重启时间记录
+	dropped             atomic.Int64                   // 丢弃的消息数
+	rejected            atomic.Int64                   // 拒绝的消息数
+	active              atomic.Int64                   // 最近活跃时间
+	passivated          atomic.Bool                    // 是否已被钝化
+	asks                sync.Map                       // 等待回复的Ask请求
+	askSeq              atomic.Int32                   // Ask请求序列号
 }
 
 // ID 获取Actor的ID
@@ -46,9 +56,14 @@ func (a *Actor) Kind() string {
 	return a.opts.kind
 }
 
+// Parent 获取父级Actor，非通过Actor衍生时返回nil
+func (a *Actor) Parent() *Actor {
+	return a.opts.parent
+}
+
 // Spawn 衍生出一个Actor
 func (a *Actor) Spawn(creator Creator, opts ...ActorOption) (*Actor, error) {
-	return a.scheduler.spawn(creator, opts...)
+	return a.scheduler.spawn(creator, append(opts, withActorParent(a))...)
 }
 
 // Proxy 获取代理API
@@ -57,7 +72,13 @@ func (a *Actor) Proxy() *Proxy {
 }
 
 // Invoke 调用函数（Actor内线程安全）
+// 邮箱溢出策略为非阻塞策略且函数队列已满时，函数将按溢出策略被丢弃并计入Dropped统计
 func (a *Actor) Invoke(fn func()) {
+	a.doInvoke(fn, false)
+}
+
+// 执行调用函数，force为true时忽略邮箱溢出策略阻塞等待函数队列空闲，用于钝化检测、快照检查点、故障上报等内部调度
+func (a *Actor) doInvoke(fn func(), force bool) {
 	a.rw.RLock()
 	defer a.rw.RUnlock()
 
@@ -65,7 +86,11 @@ func (a *Actor) Invoke(fn func()) {
 		return
 	}
 
-	a.fnChan <- fn
+	if force {
+		a.fnChan <- fn
+	} else {
+		a.invoke(fn)
+	}
 }
 
 // AfterFunc 延迟调用，与官方的time.AfterFunc用法一致
@@ -89,20 +114,19 @@ func (a *Actor) AfterFunc(d time.Duration, f func()) *Timer {
 }
 
 // AfterInvoke 延迟调用（线程安全）
+// 与Invoke一致，到期时函数队列已满将按邮箱溢出策略处理
 func (a *Actor) AfterInvoke(d time.Duration, f func()) *Timer {
+	return a.afterInvoke(d, f, false)
+}
+
+// 延迟调用，force语义与doInvoke一致
+func (a *Actor) afterInvoke(d time.Duration, f func(), force bool) *Timer {
 	if a.state.Load() != started {
 		return nil
 	}
 
 	timer := time.AfterFunc(d, func() {
-		a.rw.RLock()
-		defer a.rw.RUnlock()
-
-		if a.state.Load() != started {
-			return
-		}
-
-		a.fnChan <- f
+		a.doInvoke(f, force)
 	})
 
 	return &Timer{timer: timer}
@@ -117,6 +141,11 @@ func (a *Actor) SetDefaultRouteHandler(handler RouteHandler) {
 	case unstart:
 		a.defaultRouteHandler = handler
 	case started:
+		if a.initializing.Load() {
+			a.defaultRouteHandler = handler
+			return
+		}
+
 		a.fnChan <- func() {
 			a.defaultRouteHandler = handler
 		}
@@ -134,12 +163,13 @@ func (a *Actor) AddRouteHandler(route int32, handler RouteHandler) {
 	case unstart:
 		a.routes[route] = handler
 	case started:
-		a.fnChan <- func() {
-			a.routes[route] = handler
+		if a.initializing.Load() {
+			a.addRouteHandler(route, handler)
+			return
+		}
 
-			if a.opts.dispatch {
-				a.scheduler.routes.Store(route, a.Kind())
-			}
+		a.fnChan <- func() {
+			a.addRouteHandler(route, handler)
 		}
 	default:
 		// ignore
@@ -155,6 +185,11 @@ func (a *Actor) AddEventHandler(event cluster.Event, handler EventHandler) {
 	case unstart:
 		a.events[event] = handler
 	case started:
+		if a.initializing.Load() {
+			a.events[event] = handler
+			return
+		}
+
 		a.fnChan <- func() {
 			a.events[event] = handler
 		}
@@ -163,22 +198,52 @@ func (a *Actor) AddEventHandler(event cluster.Event, handler EventHandler) {
 	}
 }
 
+// 执行添加路由处理器
+func (a *Actor) addRouteHandler(route int32, handler RouteHandler) {
+	a.routes[route] = handler
+
+	if a.opts.dispatch {
+		a.scheduler.routes.Store(route, a.Kind())
+	}
+}
+
+// 初始化处理器
+// 初始化期间注册的处理器直接写入Actor，避免调用队列已满时阻塞衍生或重启Actor的协程
+func (a *Actor) initProcessor() {
+	a.initializing.Store(true)
+	defer a.initializing.Store(false)
+
+	a.processor.Init()
+}
+
 // Next 投递消息到Actor中进行处理
 func (a *Actor) Next(ctx Context) {
 	a.rw.RLock()
 	defer a.rw.RUnlock()
 
 	if a.state.Load() != started {
+		// 钝化前已获取到当前Actor的消息，转发到重新激活的Actor中进行处理
+		if a.passivated.Load() && ctx.Kind() != Event {
+			if act, ok := a.scheduler.activate(a.Kind(), a.ID()); ok && act != a {
+				act.Next(ctx)
+			}
+		}
+
 		return
 	}
 
 	ctx.storeActor(a)
 
-	ctx.incrVersion()
+	version := ctx.incrVersion()
 
 	ctx.Cancel()
 
-	a.mailbox <- ctx
+	if a.resolve(ctx) {
+		ctx.compareVersionRecycle(version)
+		return
+	}
+
+	a.enqueue(ctx, version)
 }
 
 // Deliver 投递消息到当前Actor中进行处理
@@ -189,7 +254,11 @@ func (a *Actor) Deliver(uid int64, message *cluster.Message) error {
 	}
 
 	req := a.scheduler.node.reqPool.Get().(*request)
+	req.ctx = context.Background()
+	req.gid = ""
 	req.nid = a.scheduler.node.opts.id
+	req.pid = ""
+	req.cid = 0
 	req.uid = uid
 	req.message.Seq = message.Seq
 	req.message.Route = message.Route
@@ -200,6 +269,28 @@ func (a *Actor) Deliver(uid int64, message *cluster.Message) error {
 	return nil
 }
 
+// Tell 投递消息给集群中的Actor进行处理，接收Actor可通过Reply回复消息给当前Actor
+func (a *Actor) Tell(ctx context.Context, args *cluster.TellArgs) error {
+	return a.scheduler.node.proxy.doTell(ctx, args, a.PID())
+}
+
+// 投递来自其他Actor或节点的消息到当前Actor中进行处理
+func (a *Actor) deliver(nid, pid string, cid, uid int64, seq, route int32, data any, headers map[string]string) {
+	req := a.scheduler.node.reqPool.Get().(*request)
+	req.ctx = context.Background()
+	req.gid = ""
+	req.nid = nid
+	req.pid = pid
+	req.cid = cid
+	req.uid = uid
+	req.message.Seq = seq
+	req.message.Route = route
+	req.message.Data = data
+	req.message.Headers = headers
+
+	a.Next(req)
+}
+
 // Push 推送消息到本地Node队列上进行处理
 func (a *Actor) Push(uid int64, message *cluster.Message) error {
 	buf, err := a.scheduler.node.proxy.PackBuffer(message.Data)
@@ -207,7 +298,7 @@ func (a *Actor) Push(uid int64, message *cluster.Message) error {
 		return err
 	}
 
-	a.scheduler.node.router.deliver("", a.scheduler.node.opts.id, a.PID(), 0, uid, message.Seq, message.Route, buf)
+	a.scheduler.node.router.deliver(context.Background(), "", a.scheduler.node.opts.id, a.PID(), 0, uid, message.Seq, message.Route, buf, false, nil, time.Time{})
 
 	return nil
 }
@@ -228,11 +319,32 @@ func (a *Actor) destroy() bool {
 		return false
 	}
 
+	a.doDestroy()
+
+	return true
+}
+
+// 执行销毁Actor，调用前需已将Actor状态置为已销毁
+func (a *Actor) doDestroy() {
+	if err := a.snapshot(); err != nil {
+		log.Errorf("actor snapshot failed, pid = %v err = %v", a.PID(), err)
+	}
+
+	if a.opts.addressable {
+		a.unbindLocation()
+	}
+
 	a.processor.Destroy()
 
+	a.report(ActorStopped, nil)
+
 	a.scheduler.batchUnbindActor(func(relations map[int64]map[string]*Actor) {
 		a.binds.Range(func(uid, _ any) bool {
-			delete(relations[uid.(int64)], a.Kind())
+			// 钝化后用户可能已绑定到重新激活的Actor，仅解除与当前Actor的绑定关系
+			if act, ok := relations[uid.(int64)][a.Kind()]; ok && act == a {
+				delete(relations[uid.(int64)], a.Kind())
+			}
+
 			return true
 		})
 	})
@@ -242,6 +354,12 @@ func (a *Actor) destroy() bool {
 
 	close(a.mailbox)
 
+	// 回收邮箱中未处理的消息并同步邮箱消息数统计
+	for ctx := range a.mailbox {
+		mailboxMessages.Add(-1, a.Kind())
+		ctx.compareVersionRecycle(ctx.loadVersion())
+	}
+
 	close(a.fnChan)
 
 	clear(a.routes)
@@ -251,8 +369,26 @@ func (a *Actor) destroy() bool {
 	a.processor = nil
 
 	a.defaultRouteHandler = nil
+}
 
-	return true
+// 绑定Actor所在节点
+func (a *Actor) bindLocation() {
+	ctx, cancel := context.WithTimeout(a.scheduler.node.ctx, defaultTimeout)
+	defer cancel()
+
+	if err := a.scheduler.node.proxy.nodeLinker.BindActor(ctx, a.Kind(), a.ID(), a.scheduler.node.opts.id); err != nil {
+		log.Errorf("bind actor location failed, pid = %v err = %v", a.PID(), err)
+	}
+}
+
+// 解绑Actor所在节点
+func (a *Actor) unbindLocation() {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	if err := a.scheduler.node.proxy.nodeLinker.UnbindActor(ctx, a.Kind(), a.ID(), a.scheduler.node.opts.id); err != nil {
+		log.Errorf("unbind actor location failed, pid = %v err = %v", a.PID(), err)
+	}
 }
 
 // 绑定用户
@@ -275,21 +411,25 @@ func (a *Actor) dispatch() {
 				return
 			}
 
+			mailboxMessages.Add(-1, a.Kind())
+
+			a.active.Store(time.Now().UnixNano())
+
 			version := ctx.loadVersion()
 
 			if ctx.Kind() == Event {
 				if handler, ok := a.events[ctx.Event()]; ok {
-					xcall.Call(func() { handler(ctx) })
+					a.call(func() { handler(ctx) })
 
 					ctx.compareVersionExecDefer(version)
 				}
 			} else {
 				if handler, ok := a.routes[ctx.Route()]; ok {
-					xcall.Call(func() { handler(ctx) })
+					a.call(func() { handler(ctx) })
 
 					ctx.compareVersionExecDefer(version)
 				} else if a.defaultRouteHandler != nil {
-					xcall.Call(func() { a.defaultRouteHandler(ctx) })
+					a.call(func() { a.defaultRouteHandler(ctx) })
 
 					ctx.compareVersionExecDefer(version)
 				}
@@ -301,7 +441,7 @@ func (a *Actor) dispatch() {
 				return
 			}
 
-			xcall.Call(handle)
+			a.call(handle)
 		}
 	}
 }
@@ -1,17 +1,29 @@
 package node
 
+import "time"
+
+const defaultMailboxCapacity = 4096 // 默认邮箱容量
+
 type actorOptions struct {
-	id       string // Actor编号
-	kind     string // Actor类型
-	args     []any  // 传递到Processor中的参数
-	wait     bool   // 是否需要等待
-	dispatch bool   // 是否接受调度器调度
+	id          string         // Actor编号
+	kind        string         // Actor类型
+	args        []any          // 传递到Processor中的参数
+	wait        bool           // 是否需要等待
+	dispatch    bool           // 是否接受调度器调度
+	addressable bool           // 是否可被集群寻址
+	store       SnapshotStore  // 快照存储器
+	checkpoint  time.Duration  // 快照检查点间隔
+	supervisor  *Supervisor    // 监督者
+	parent      *Actor         // 父级Actor
+	mailbox     int            // 邮箱容量
+	overflow    OverflowPolicy // 邮箱溢出策略
+	passivation time.Duration  // 钝化时长
 }
 
 type ActorOption func(o *actorOptions)
 
 func defaultActorOptions() *actorOptions {
-	return &actorOptions{wait: true, dispatch: true}
+	return &actorOptions{wait: true, dispatch: true, mailbox: defaultMailboxCapacity}
 }
 
 // WithActorID 设置Actor编号
@@ -38,3 +50,50 @@ func WithActorNonWait() ActorOption {
 func WithActorNonDispatch() ActorOption {
 	return func(o *actorOptions) { o.dispatch = false }
 }
+
+// WithActorSnapshotStore 设置Actor快照存储器，未设置时使用节点的快照存储器
+func WithActorSnapshotStore(store SnapshotStore) ActorOption {
+	return func(o *actorOptions) { o.store = store }
+}
+
+// WithActorCheckpoint 设置Actor定期保存快照的间隔时间
+// 仅对实现了Persistent接口的Processor生效
+func WithActorCheckpoint(interval time.Duration) ActorOption {
+	return func(o *actorOptions) { o.checkpoint = interval }
+}
+
+// WithActorAddressable 设置Actor可被集群中的其他节点寻址
+// 需定位器实现locate.ActorLocator接口，Actor衍生时会将其所在节点注册到定位器中
+func WithActorAddressable() ActorOption {
+	return func(o *actorOptions) { o.addressable = true }
+}
+
+// WithActorSupervisor 设置Actor监督者，处理器发生异常时根据监督策略进行重启、停止或上报给父级Actor
+func WithActorSupervisor(supervisor *Supervisor) ActorOption {
+	return func(o *actorOptions) { o.supervisor = supervisor }
+}
+
+// 设置父级Actor
+func withActorParent(parent *Actor) ActorOption {
+	return func(o *actorOptions) { o.parent = parent }
+}
+
+// WithActorMailbox 设置Actor邮箱容量与溢出策略，默认容量为4096，溢出时阻塞等待
+func WithActorMailbox(capacity int, overflow ...OverflowPolicy) ActorOption {
+	return func(o *actorOptions) {
+		if capacity > 0 {
+			o.mailbox = capacity
+		}
+
+		if len(overflow) > 0 {
+			o.overflow = overflow[0]
+		}
+	}
+}
+
+// WithActorPassivation 设置Actor钝化时长
+// Actor在钝化时长内未处理任何邮箱消息时，将解绑所有用户并销毁；
+// 若已通过Proxy.RegisterActorCreator注册该类型的创建器，后续路由到该Actor的请求会重新衍生Actor
+func WithActorPassivation(idle time.Duration) ActorOption {
+	return func(o *actorOptions) { o.passivation = idle }
+}
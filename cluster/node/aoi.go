@@ -0,0 +1,44 @@
+package node
+
+import (
+	"context"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/core/aoi"
+	"github.com/dobyte/due/v2/session"
+)
+
+// MulticastArea 向AOI中用户视野内的所有用户推送组播消息，AOI中的实体ID即为用户ID
+// self为true时同时推送给用户自身；视野内没有其他用户时不进行推送
+// AOI非线程安全，需在持有AOI的Actor的处理协程中调用
+func (p *Proxy) MulticastArea(ctx context.Context, area aoi.AOI, uid int64, message *cluster.Message, self ...bool) error {
+	targets := area.Neighbors(uid)
+
+	if len(self) > 0 && self[0] {
+		if _, _, ok := area.Position(uid); ok {
+			targets = append(targets, uid)
+		}
+	}
+
+	return p.multicastUsers(ctx, targets, message)
+}
+
+// MulticastRange 向AOI中以(x, y)为圆心、radius为半径的范围内的所有用户推送组播消息，AOI中的实体ID即为用户ID
+// 范围内没有用户时不进行推送
+// AOI非线程安全，需在持有AOI的Actor的处理协程中调用
+func (p *Proxy) MulticastRange(ctx context.Context, area aoi.AOI, x, y, radius float64, message *cluster.Message) error {
+	return p.multicastUsers(ctx, area.Range(x, y, radius), message)
+}
+
+// 推送组播消息给用户
+func (p *Proxy) multicastUsers(ctx context.Context, uids []int64, message *cluster.Message) error {
+	if len(uids) == 0 {
+		return nil
+	}
+
+	return p.gateLinker.Multicast(ctx, &cluster.MulticastArgs{
+		Kind:    session.User,
+		Targets: uids,
+		Message: message,
+	})
+}
@@ -0,0 +1,151 @@
+package node
+
+import (
+	"context"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+)
+
+const (
+	askHeader  = "due-ask" // Ask消息标识头
+	askRequest = "request" // Ask请求
+	askReply   = "reply"   // Ask请求的回复
+)
+
+type Reply struct {
+	node  *Node  // 节点服务器
+	seq   int32  // 序列号
+	route int32  // 路由号
+	data  []byte // 消息数据
+}
+
+// Seq 获取消息序列号
+func (r *Reply) Seq() int32 {
+	return r.seq
+}
+
+// Route 获取消息路由号
+func (r *Reply) Route() int32 {
+	return r.route
+}
+
+// Parse 解析消息
+func (r *Reply) Parse(v any) error {
+	if len(r.data) == 0 {
+		return nil
+	}
+
+	return r.node.opts.codec.Unmarshal(r.data, v)
+}
+
+type askResult struct {
+	reply *Reply
+	err   error
+}
+
+// Ask 向集群中的Actor发送消息并等待回复，目标Actor通过ctx.Reply或ctx.Response进行回复
+// Ask会阻塞调用方协程直至收到回复或超时，ctx未设置超时时间时使用节点的RPC调用超时时间
+// 在Actor的处理函数中调用时，当前Actor在等待期间无法处理邮箱中的消息，两个Actor相互Ask将互相等待至超时，此时应使用AskInvoke
+// 不允许Ask当前Actor自身；超时后收到的回复将被丢弃；目标Actor位于其他节点时，需定位器实现locate.ActorLocator接口
+func (a *Actor) Ask(ctx context.Context, target string, message *cluster.Message) (*Reply, error) {
+	if target == a.PID() {
+		return nil, errors.ErrIllegalOperation
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.scheduler.node.opts.timeout)
+		defer cancel()
+	}
+
+	seq := a.doGenAskSequence()
+
+	call := make(chan *askResult, 1)
+
+	a.asks.Store(seq, call)
+	defer a.asks.Delete(seq)
+
+	err := a.scheduler.node.proxy.doTell(ctx, &cluster.TellArgs{
+		PID: target,
+		Message: &cluster.Message{
+			Seq:     seq,
+			Route:   message.Route,
+			Data:    message.Data,
+			Headers: withAskHeader(message.Headers, askRequest),
+		},
+	}, a.PID())
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-call:
+		return res.reply, res.err
+	}
+}
+
+// AskInvoke 向集群中的Actor发送消息，收到回复或超时后在当前Actor的处理协程中执行回调函数
+// 与Ask不同，等待回复期间不会阻塞当前Actor的处理协程；回调函数不受邮箱溢出策略影响，但当前Actor在收到回复前被销毁时，回调函数将不会被执行
+func (a *Actor) AskInvoke(ctx context.Context, target string, message *cluster.Message, fn func(reply *Reply, err error)) {
+	a.scheduler.node.addWait()
+
+	go func() {
+		defer a.scheduler.node.doneWait()
+
+		reply, err := a.Ask(ctx, target, message)
+
+		a.doInvoke(func() { fn(reply, err) }, true)
+	}()
+}
+
+// 匹配Ask请求的回复消息
+// 仅携带Ask回复标识头且不来源于网关的请求视为Ask请求的回复，Ask请求已超时的回复将被丢弃
+func (a *Actor) resolve(ctx Context) bool {
+	req, ok := ctx.(*request)
+	if !ok || req.gid != "" || req.message.Headers[askHeader] != askReply {
+		return false
+	}
+
+	call, ok := a.asks.LoadAndDelete(req.message.Seq)
+	if !ok {
+		log.Debugf("ask reply is dropped after timeout, pid = %v seq = %v route = %v", a.PID(), req.message.Seq, req.message.Route)
+		return true
+	}
+
+	reply := &Reply{node: a.scheduler.node, seq: req.message.Seq, route: req.message.Route}
+
+	if data, ok := req.message.Data.([]byte); ok {
+		reply.data = data
+	}
+
+	call.(chan *askResult) <- &askResult{reply: reply}
+
+	return true
+}
+
+// 生成Ask请求序列号，Ask请求使用负数序列号以区别于客户端消息
+func (a *Actor) doGenAskSequence() int32 {
+	for {
+		if seq := a.askSeq.Add(-1); seq < 0 {
+			return seq
+		}
+
+		a.askSeq.Store(0)
+	}
+}
+
+// 复制扩展头并设置Ask消息标识头
+func withAskHeader(headers map[string]string, value string) map[string]string {
+	dst := make(map[string]string, len(headers)+1)
+	for key, val := range headers {
+		dst[key] = val
+	}
+
+	dst[askHeader] = value
+
+	return dst
+}
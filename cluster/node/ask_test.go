@@ -0,0 +1,199 @@
+package node_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/cluster/node"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+)
+
+const (
+	askRoute     = 1
+	askSlowRoute = 2
+)
+
+type askProcessor struct {
+	node.BaseProcessor
+	actor    *node.Actor
+	received *atomic.Int32
+}
+
+func (p *askProcessor) Init() {
+	p.actor.AddRouteHandler(askRoute, func(ctx node.Context) {
+		p.received.Add(1)
+
+		_ = ctx.Response(map[string]string{"message": "pong"})
+	})
+
+	p.actor.AddRouteHandler(askSlowRoute, func(ctx node.Context) {
+		p.received.Add(1)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_ = ctx.Response(map[string]string{"message": "late"})
+	})
+}
+
+// 衍生Ask请求的来源Actor与目标Actor，opts作用于来源Actor
+func spawnAskActors(t *testing.T, opts ...node.ActorOption) (source, target *node.Actor, received *atomic.Int32) {
+	t.Helper()
+
+	proxy := node.NewNode(node.WithCodec(json.DefaultCodec)).Proxy()
+	received = &atomic.Int32{}
+
+	creator := func(actor *node.Actor, _ ...any) node.Processor {
+		return &askProcessor{actor: actor, received: received}
+	}
+
+	source, err := proxy.Spawn(creator, append([]node.ActorOption{node.WithActorKind("ask"), node.WithActorID("source"), node.WithActorNonDispatch()}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target, err = proxy.Spawn(creator, node.WithActorKind("ask"), node.WithActorID("target"), node.WithActorNonDispatch())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		source.Destroy()
+		target.Destroy()
+	})
+
+	return source, target, received
+}
+
+func TestActor_Ask(t *testing.T) {
+	source, target, received := spawnAskActors(t)
+
+	reply, err := source.Ask(context.Background(), target.PID(), &cluster.Message{Route: askRoute})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make(map[string]string)
+	if err = reply.Parse(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.Route() != askRoute || res["message"] != "pong" {
+		t.Fatalf("unexpected reply, route: %d data: %v", reply.Route(), res)
+	}
+
+	if n := received.Load(); n != 1 {
+		t.Fatalf("received count mismatch, want: 1 got: %d", n)
+	}
+}
+
+func TestActor_AskSelf(t *testing.T) {
+	source, _, _ := spawnAskActors(t)
+
+	if _, err := source.Ask(context.Background(), source.PID(), &cluster.Message{Route: askRoute}); !errors.Is(err, errors.ErrIllegalOperation) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestActor_AskTimeout(t *testing.T) {
+	source, target, received := spawnAskActors(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := source.Ask(ctx, target.PID(), &cluster.Message{Route: askSlowRoute}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 超时后收到的回复不应进入来源Actor的路由处理器
+	time.Sleep(200 * time.Millisecond)
+
+	if n := received.Load(); n != 1 {
+		t.Fatalf("late reply reached route handler, received: %d", n)
+	}
+}
+
+func TestActor_AskInvoke(t *testing.T) {
+	source, target, _ := spawnAskActors(t)
+
+	done := make(chan string, 1)
+
+	source.AskInvoke(context.Background(), target.PID(), &cluster.Message{Route: askRoute}, func(reply *node.Reply, err error) {
+		if err != nil {
+			done <- err.Error()
+			return
+		}
+
+		res := make(map[string]string)
+		_ = reply.Parse(&res)
+		done <- res["message"]
+	})
+
+	select {
+	case message := <-done:
+		if message != "pong" {
+			t.Fatalf("unexpected reply: %s", message)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ask invoke callback is not executed")
+	}
+}
+
+func TestActor_AskInvokeOverflow(t *testing.T) {
+	source, target, _ := spawnAskActors(t, node.WithActorMailbox(1, node.OverflowDropNewest))
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+
+	source.Invoke(func() {
+		close(blocked)
+		<-release
+	})
+
+	<-blocked
+
+	// 占满函数队列，非阻塞溢出策略下后续的Invoke将被丢弃
+	source.Invoke(func() {})
+
+	done := make(chan error, 1)
+
+	source.AskInvoke(context.Background(), target.PID(), &cluster.Message{Route: askRoute}, func(_ *node.Reply, err error) {
+		done <- err
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	close(release)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ask invoke callback is dropped by overflow policy")
+	}
+}
+
+func TestActor_TellNegativeSeq(t *testing.T) {
+	_, target, received := spawnAskActors(t)
+
+	// 未携带Ask回复标识头的负数序列号消息应交由路由处理器处理
+	if err := target.Proxy().Tell(context.Background(), &cluster.TellArgs{
+		PID:     target.PID(),
+		Message: &cluster.Message{Seq: -1, Route: askRoute},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for deadline := time.Now().Add(time.Second); received.Load() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("message with negative seq is swallowed as ask reply")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
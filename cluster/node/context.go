@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/transport"
 )
 
@@ -25,6 +26,12 @@ type Context interface {
 	Event() cluster.Event
 	// Kind 上下文消息类型
 	Kind() Kind
+	// Header 获取消息扩展头
+	Header(key string) string
+	// Headers 获取消息的所有扩展头
+	Headers() map[string]string
+	// SetReplyHeader 设置回复消息的扩展头
+	SetReplyHeader(key, value string)
 	// Parse 解析消息
 	Parse(v any) error
 	// Defer 添加defer延迟调用栈
@@ -49,6 +56,9 @@ type Context interface {
 	SetValue(key, val any)
 	// GetValue 获取上下文中的值
 	GetValue(key any) any
+	// Logger 获取携带网关ID、连接ID、用户ID、路由号及序列号等字段的日志记录器
+	// 上下文中存在链路上下文时同时携带链路ID字段，便于按用户或链路筛选日志
+	Logger() log.Logger
 	// GetIP 获取客户端IP
 	GetIP() (string, error)
 	// Deliver 投递消息给节点处理
@@ -0,0 +1,99 @@
+package node
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+)
+
+const (
+	expiryPending int32 = iota // 处理中
+	expiryDone                 // 已处理完成
+	expiryExpired              // 已超时
+)
+
+type expiry struct {
+	state  atomic.Int32       // 状态
+	timer  *time.Timer        // 超时定时器
+	cancel context.CancelFunc // 上下文取消函数
+}
+
+// 释放超时控制
+func (e *expiry) release() {
+	e.state.CompareAndSwap(expiryPending, expiryDone)
+	e.timer.Stop()
+	e.cancel()
+}
+
+// 为请求设置截止时间，返回false表示请求在处理前已超时
+// 截止时间取投递时携带的截止时间与路由超时时间中较早的一个
+func (r *request) withDeadline(timeout time.Duration) bool {
+	deadline := r.deadline
+
+	if timeout > 0 {
+		if d := time.Now().Add(timeout); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+
+	if deadline.IsZero() {
+		return true
+	}
+
+	if !time.Now().Before(deadline) {
+		r.node.replyCode(r, codes.DeadlineExceeded)
+		return false
+	}
+
+	var (
+		e     = &expiry{}
+		node  = r.node
+		gid   = r.gid
+		nid   = r.nid
+		pid   = r.pid
+		cid   = r.cid
+		uid   = r.uid
+		seq   = r.message.Seq
+		route = r.message.Route
+	)
+
+	r.ctx, e.cancel = context.WithDeadline(r.ctx, deadline)
+
+	// 请求对象可能在超时后被回收复用，因此超时回复使用独立的请求对象
+	e.timer = time.AfterFunc(time.Until(deadline), func() {
+		if !e.state.CompareAndSwap(expiryPending, expiryExpired) {
+			return
+		}
+
+		req := &request{
+			node:    node,
+			ctx:     context.Background(),
+			gid:     gid,
+			nid:     nid,
+			pid:     pid,
+			cid:     cid,
+			uid:     uid,
+			message: &cluster.Message{Seq: seq, Route: route},
+		}
+		req.actor.Store((*Actor)(nil))
+
+		node.replyCode(req, codes.DeadlineExceeded)
+	})
+
+	r.expiry = e
+
+	return true
+}
+
+// 抢占回复权，返回false表示请求已超时且超时回复已发出
+// 抢占成功后超时定时器不再发送超时回复，保证同一请求只会发出真实回复或超时回复中的一个
+func (r *request) claim() bool {
+	if r.expiry == nil {
+		return true
+	}
+
+	return r.expiry.state.CompareAndSwap(expiryPending, expiryDone) || r.expiry.state.Load() == expiryDone
+}
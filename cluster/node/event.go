@@ -8,6 +8,7 @@ import (
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/core/chains"
 	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/session"
 	"github.com/dobyte/due/v2/task"
 	"github.com/dobyte/due/v2/transport"
@@ -65,6 +66,19 @@ func (e *event) Kind() Kind {
 	return Event
 }
 
+// Header 获取消息扩展头
+func (e *event) Header(key string) string {
+	return ""
+}
+
+// Headers 获取消息的所有扩展头
+func (e *event) Headers() map[string]string {
+	return nil
+}
+
+// SetReplyHeader 设置回复消息的扩展头，事件无回复消息，调用无效
+func (e *event) SetReplyHeader(key, value string) {}
+
 // Parse 解析消息
 func (e *event) Parse(v any) error {
 	return errors.NewError(errors.ErrIllegalOperation)
@@ -165,6 +179,16 @@ func (e *event) GetValue(key any) any {
 	return e.ctx.Value(key)
 }
 
+// Logger 获取携带事件字段的日志记录器
+func (e *event) Logger() log.Logger {
+	return newContextLogger(e.ctx,
+		log.Any("gid", e.gid),
+		log.Any("cid", e.cid),
+		log.Any("uid", e.uid),
+		log.Any("event", e.event.String()),
+	)
+}
+
 // BindGate 绑定网关
 func (e *event) BindGate(uid ...int64) error {
 	switch {
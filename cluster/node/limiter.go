@@ -0,0 +1,118 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/core/limiter"
+)
+
+// UIDLimiter 用户级限流中间件
+// 每个用户独立拥有一个容量为capacity、每秒补充rate个令牌的令牌桶，超出限制时向客户端回复codes.TooManyRequests
+func UIDLimiter(capacity, rate float64) MiddlewareHandler {
+	limiters := newKeyedLimiter(capacity, rate)
+
+	return func(middleware *Middleware, ctx Context) {
+		if !limiters.allow(ctx.UID()) {
+			ctx.Proxy().node.replyCode(ctx, codes.TooManyRequests)
+			return
+		}
+
+		middleware.Next(ctx)
+	}
+}
+
+// RouteLimiter 路由级限流中间件
+// 每个路由独立拥有一个容量为capacity、每秒补充rate个令牌的令牌桶，超出限制时向客户端回复codes.TooManyRequests
+func RouteLimiter(capacity, rate float64) MiddlewareHandler {
+	limiters := newKeyedLimiter(capacity, rate)
+
+	return func(middleware *Middleware, ctx Context) {
+		if !limiters.allow(ctx.Route()) {
+			ctx.Proxy().node.replyCode(ctx, codes.TooManyRequests)
+			return
+		}
+
+		middleware.Next(ctx)
+	}
+}
+
+// GlobalLimiter 全局限流中间件
+// 使用中间件的所有路由共享一个容量为capacity、每秒补充rate个令牌的令牌桶，超出限制时向客户端回复codes.TooManyRequests
+func GlobalLimiter(capacity, rate float64) MiddlewareHandler {
+	l := limiter.NewLimiter(capacity, rate)
+
+	return func(middleware *Middleware, ctx Context) {
+		if !l.Allow() {
+			ctx.Proxy().node.replyCode(ctx, codes.TooManyRequests)
+			return
+		}
+
+		middleware.Next(ctx)
+	}
+}
+
+type keyedLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	rate     float64
+	idle     time.Duration
+	sweepAt  time.Time
+	limiters map[any]*keyedLimiterEntry
+}
+
+type keyedLimiterEntry struct {
+	limiter *limiter.Limiter
+	active  time.Time
+}
+
+func newKeyedLimiter(capacity, rate float64) *keyedLimiter {
+	l := &keyedLimiter{
+		capacity: capacity,
+		rate:     rate,
+		sweepAt:  time.Now(),
+		limiters: make(map[any]*keyedLimiterEntry),
+	}
+
+	// 令牌桶闲置超过此时长后必然已被填满，回收后重新创建不会影响限流效果
+	if rate > 0 {
+		l.idle = max(time.Duration(capacity/rate*float64(time.Second)), time.Second)
+	}
+
+	return l
+}
+
+// 检测是否允许通过
+func (l *keyedLimiter) allow(key any) bool {
+	l.mu.Lock()
+
+	now := time.Now()
+
+	if l.idle > 0 && now.Sub(l.sweepAt) >= l.idle {
+		l.sweep(now)
+	}
+
+	entry, ok := l.limiters[key]
+	if !ok {
+		entry = &keyedLimiterEntry{limiter: limiter.NewLimiter(l.capacity, l.rate)}
+		l.limiters[key] = entry
+	}
+
+	entry.active = now
+
+	l.mu.Unlock()
+
+	return entry.limiter.Allow()
+}
+
+// 回收闲置的令牌桶
+func (l *keyedLimiter) sweep(now time.Time) {
+	for key, entry := range l.limiters {
+		if now.Sub(entry.active) >= l.idle {
+			delete(l.limiters, key)
+		}
+	}
+
+	l.sweepAt = now
+}
@@ -0,0 +1,89 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dobyte/due/v2/codes"
+)
+
+type limiterRecorder struct {
+	node    *Node
+	handled int
+	limited int
+}
+
+func newLimiterRecorder() *limiterRecorder {
+	r := &limiterRecorder{}
+	r.node = NewNode(WithCodeReplyHandler(func(ctx Context, code *codes.Code) error {
+		if code == codes.TooManyRequests {
+			r.limited++
+		}
+
+		return nil
+	}))
+
+	return r
+}
+
+// 经过限流中间件处理一个请求
+func (r *limiterRecorder) handle(handler MiddlewareHandler, uid int64, route int32) {
+	req := r.node.reqPool.Get().(*request)
+	req.ctx = context.Background()
+	req.uid = uid
+	req.message.Route = route
+
+	middleware := &Middleware{}
+	middleware.reset([]MiddlewareHandler{handler}, func(ctx Context) { r.handled++ })
+	middleware.Next(req)
+}
+
+func (r *limiterRecorder) expect(t *testing.T, handled, limited int) {
+	t.Helper()
+
+	if r.handled != handled || r.limited != limited {
+		t.Fatalf("unexpected result, want handled: %d limited: %d got handled: %d limited: %d", handled, limited, r.handled, r.limited)
+	}
+}
+
+func TestUIDLimiter(t *testing.T) {
+	r := newLimiterRecorder()
+	handler := UIDLimiter(2, 0.001)
+
+	for i := 0; i < 3; i++ {
+		r.handle(handler, 1, 1)
+	}
+
+	r.expect(t, 2, 1)
+
+	// 不同用户拥有独立的令牌桶
+	r.handle(handler, 2, 1)
+
+	r.expect(t, 3, 1)
+}
+
+func TestRouteLimiter(t *testing.T) {
+	r := newLimiterRecorder()
+	handler := RouteLimiter(1, 0.001)
+
+	r.handle(handler, 1, 1)
+	r.handle(handler, 2, 1)
+
+	r.expect(t, 1, 1)
+
+	// 不同路由拥有独立的令牌桶
+	r.handle(handler, 1, 2)
+
+	r.expect(t, 2, 1)
+}
+
+func TestGlobalLimiter(t *testing.T) {
+	r := newLimiterRecorder()
+	handler := GlobalLimiter(2, 0.001)
+
+	r.handle(handler, 1, 1)
+	r.handle(handler, 2, 2)
+	r.handle(handler, 3, 3)
+
+	r.expect(t, 2, 1)
+}
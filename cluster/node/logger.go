@@ -0,0 +1,17 @@
+package node
+
+import (
+	"context"
+
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/trace"
+)
+
+// 创建携带上下文字段的日志记录器，存在链路上下文时追加链路ID字段
+func newContextLogger(ctx context.Context, fields ...log.Field) log.Logger {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, log.Any("traceId", sc.TraceID.String()))
+	}
+
+	return log.WithFields(log.FromContext(ctx), fields...)
+}
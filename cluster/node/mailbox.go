@@ -0,0 +1,112 @@
+package node
+
+import (
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/log"
+)
+
+// OverflowPolicy 邮箱溢出策略
+type OverflowPolicy int
+
+const (
+	OverflowBlock      OverflowPolicy = iota // 阻塞等待邮箱空闲
+	OverflowDropNewest                       // 丢弃最新投递的消息
+	OverflowDropOldest                       // 丢弃邮箱中最旧的消息
+	OverflowReject                           // 拒绝最新投递的消息，并回复codes.TooManyRequests给客户端
+)
+
+// ActorStats Actor统计信息
+type ActorStats struct {
+	MailboxSize     int   // 邮箱中待处理的消息数
+	MailboxCapacity int   // 邮箱容量
+	Dropped         int64 // 因邮箱溢出而丢弃的消息数（含调用函数）
+	Rejected        int64 // 因邮箱溢出而拒绝的消息数
+}
+
+// Stats 获取Actor统计信息
+func (a *Actor) Stats() ActorStats {
+	return ActorStats{
+		MailboxSize:     len(a.mailbox),
+		MailboxCapacity: cap(a.mailbox),
+		Dropped:         a.dropped.Load(),
+		Rejected:        a.rejected.Load(),
+	}
+}
+
+// 投递消息到邮箱
+func (a *Actor) enqueue(ctx Context, version int32) {
+	switch a.opts.overflow {
+	case OverflowDropNewest:
+		select {
+		case a.mailbox <- ctx:
+			mailboxMessages.Add(1, a.Kind())
+		default:
+			a.dropped.Add(1)
+			mailboxDropped.Add(1, a.Kind())
+			ctx.compareVersionRecycle(version)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case a.mailbox <- ctx:
+				mailboxMessages.Add(1, a.Kind())
+				return
+			default:
+			}
+
+			select {
+			case old := <-a.mailbox:
+				a.dropped.Add(1)
+				mailboxMessages.Add(-1, a.Kind())
+				mailboxDropped.Add(1, a.Kind())
+				old.compareVersionRecycle(old.loadVersion())
+			default:
+			}
+		}
+	case OverflowReject:
+		select {
+		case a.mailbox <- ctx:
+			mailboxMessages.Add(1, a.Kind())
+		default:
+			a.rejected.Add(1)
+			mailboxRejected.Add(1, a.Kind())
+			a.scheduler.node.replyCode(ctx, codes.TooManyRequests)
+			ctx.compareVersionRecycle(version)
+		}
+	default:
+		mailboxMessages.Add(1, a.Kind())
+		a.mailbox <- ctx
+	}
+}
+
+// 投递调用函数到函数队列
+func (a *Actor) invoke(fn func()) {
+	switch a.opts.overflow {
+	case OverflowDropNewest, OverflowReject:
+		select {
+		case a.fnChan <- fn:
+		default:
+			a.dropped.Add(1)
+			mailboxDropped.Add(1, a.Kind())
+			log.Warnf("actor invoke queue is full and the function is dropped, pid = %v", a.PID())
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case a.fnChan <- fn:
+				return
+			default:
+			}
+
+			select {
+			case <-a.fnChan:
+				a.dropped.Add(1)
+				mailboxDropped.Add(1, a.Kind())
+				log.Warnf("actor invoke queue is full and the oldest function is dropped, pid = %v", a.PID())
+			default:
+			}
+		}
+	default:
+		a.fnChan <- fn
+	}
+}
@@ -0,0 +1,197 @@
+package node_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/cluster/node"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/metrics"
+)
+
+type mailboxProcessor struct {
+	node.BaseProcessor
+	actor   *node.Actor
+	handled chan int32
+}
+
+func (p *mailboxProcessor) Init() {
+	for route := int32(1); route <= 3; route++ {
+		p.actor.AddRouteHandler(route, func(ctx node.Context) {
+			p.handled <- ctx.Route()
+		})
+	}
+}
+
+// 以容量为1的邮箱衍生Actor，并阻塞其处理协程直至release被关闭
+func spawnBlocked(t *testing.T, n *node.Node, overflow node.OverflowPolicy) (*node.Actor, chan int32, chan struct{}) {
+	t.Helper()
+
+	handled := make(chan int32, 3)
+
+	actor, err := n.Proxy().Spawn(func(actor *node.Actor, _ ...any) node.Processor {
+		return &mailboxProcessor{actor: actor, handled: handled}
+	}, node.WithActorKind("mailbox"), node.WithActorID("1"), node.WithActorMailbox(1, overflow))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+
+	actor.Invoke(func() {
+		close(blocked)
+		<-release
+	})
+
+	<-blocked
+
+	return actor, handled, release
+}
+
+// 阻塞期间投递三条消息与两个调用函数，返回实际被执行的调用函数
+func overflow(t *testing.T, actor *node.Actor) chan int {
+	t.Helper()
+
+	for route := int32(1); route <= 3; route++ {
+		if err := actor.Deliver(1, &cluster.Message{Route: route, Data: []byte("ping")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	invoked := make(chan int, 2)
+
+	for i := 1; i <= 2; i++ {
+		i := i
+
+		done := make(chan struct{})
+		go func() {
+			actor.Invoke(func() { invoked <- i })
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("invoke is blocked under non-blocking overflow policy")
+		}
+	}
+
+	return invoked
+}
+
+func expectReceived[T comparable](t *testing.T, ch chan T, want T) {
+	t.Helper()
+
+	select {
+	case got := <-ch:
+		if got != want {
+			t.Fatalf("received mismatch, want: %v got: %v", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("wait %v timeout", want)
+	}
+
+	select {
+	case got := <-ch:
+		t.Fatalf("unexpected received: %v", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func expectStats(t *testing.T, actor *node.Actor, want node.ActorStats) {
+	t.Helper()
+
+	if got := actor.Stats(); got != want {
+		t.Fatalf("stats mismatch, want: %+v got: %+v", want, got)
+	}
+}
+
+func TestMailbox_DropNewest(t *testing.T) {
+	n := node.NewNode()
+
+	actor, handled, release := spawnBlocked(t, n, node.OverflowDropNewest)
+	defer n.Proxy().Kill(actor.Kind(), actor.ID())
+
+	invoked := overflow(t, actor)
+
+	expectStats(t, actor, node.ActorStats{MailboxSize: 1, MailboxCapacity: 1, Dropped: 3})
+
+	close(release)
+
+	expectReceived(t, handled, 1)
+	expectReceived(t, invoked, 1)
+}
+
+func TestMailbox_DropOldest(t *testing.T) {
+	n := node.NewNode()
+
+	actor, handled, release := spawnBlocked(t, n, node.OverflowDropOldest)
+	defer n.Proxy().Kill(actor.Kind(), actor.ID())
+
+	invoked := overflow(t, actor)
+
+	expectStats(t, actor, node.ActorStats{MailboxSize: 1, MailboxCapacity: 1, Dropped: 3})
+
+	close(release)
+
+	expectReceived(t, handled, 3)
+	expectReceived(t, invoked, 2)
+}
+
+func TestMailbox_Reject(t *testing.T) {
+	var rejected atomic.Int32
+
+	n := node.NewNode(node.WithCodeReplyHandler(func(ctx node.Context, code *codes.Code) error {
+		if code == codes.TooManyRequests {
+			rejected.Add(1)
+		}
+
+		return nil
+	}))
+
+	actor, handled, release := spawnBlocked(t, n, node.OverflowReject)
+	defer n.Proxy().Kill(actor.Kind(), actor.ID())
+
+	invoked := overflow(t, actor)
+
+	expectStats(t, actor, node.ActorStats{MailboxSize: 1, MailboxCapacity: 1, Dropped: 1, Rejected: 2})
+
+	if rejected.Load() != 2 {
+		t.Fatalf("rejected messages should be replied, replies: %d", rejected.Load())
+	}
+
+	close(release)
+
+	expectReceived(t, handled, 1)
+	expectReceived(t, invoked, 1)
+}
+
+func TestMailbox_DestroyGauge(t *testing.T) {
+	registry := metrics.NewRegistry()
+	metrics.SetRegistry(registry)
+	defer metrics.SetRegistry(metrics.NewRegistry())
+
+	n := node.NewNode()
+
+	actor, _, release := spawnBlocked(t, n, node.OverflowBlock)
+	defer close(release)
+
+	if err := actor.Deliver(1, &cluster.Message{Route: 1, Data: []byte("ping")}); err != nil {
+		t.Fatal(err)
+	}
+
+	n.Proxy().Kill(actor.Kind(), actor.ID())
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if line := `due_actor_mailbox_messages{kind="mailbox"} 0`; !strings.Contains(recorder.Body.String(), line+"\n") {
+		t.Fatalf("mailbox gauge is not decremented after destroy:\n%s", recorder.Body.String())
+	}
+}
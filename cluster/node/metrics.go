@@ -0,0 +1,29 @@
+package node
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dobyte/due/v2/metrics"
+)
+
+var (
+	handledMessages = metrics.NewCounter("due_node_handled_messages_total", "Total number of messages handled by the node router.", "route")
+	handleDuration  = metrics.NewHistogram("due_node_handle_duration_seconds", "Duration of message handling in the node router.", nil, "route")
+	mailboxMessages = metrics.NewGauge("due_actor_mailbox_messages", "Current number of messages waiting in actor mailboxes.", "kind")
+	mailboxDropped  = metrics.NewCounter("due_actor_mailbox_dropped_total", "Total number of messages dropped due to actor mailbox overflow.", "kind")
+	mailboxRejected = metrics.NewCounter("due_actor_mailbox_rejected_total", "Total number of messages rejected due to actor mailbox overflow.", "kind")
+)
+
+const unknownRoute = "unknown" // 未注册路由的统一标签，避免客户端通过任意路由号制造无限的时间序列
+
+// 统计路由消息处理，未注册的路由由默认路由处理器处理，统一使用unknown标签
+func observeHandle(route int32, registered bool, start time.Time) {
+	label := unknownRoute
+	if registered {
+		label = strconv.Itoa(int(route))
+	}
+
+	handledMessages.Add(1, label)
+	handleDuration.Observe(time.Since(start).Seconds(), label)
+}
@@ -0,0 +1,41 @@
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/metrics"
+)
+
+func TestObserveHandle(t *testing.T) {
+	registry := metrics.NewRegistry()
+	metrics.SetRegistry(registry)
+	defer metrics.SetRegistry(metrics.NewRegistry())
+
+	// 由默认路由处理器处理的未注册路由统一使用unknown标签
+	for route := int32(1000); route < 1010; route++ {
+		observeHandle(route, false, time.Now())
+	}
+
+	observeHandle(1, true, time.Now())
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	text := recorder.Body.String()
+
+	for _, line := range []string{
+		`due_node_handled_messages_total{route="unknown"} 10`,
+		`due_node_handled_messages_total{route="1"} 1`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Fatalf("missing line %q in:\n%s", line, text)
+		}
+	}
+
+	if strings.Contains(text, `route="1000"`) {
+		t.Fatalf("unregistered route should not be labelled:\n%s", text)
+	}
+}
@@ -3,6 +3,8 @@ package node
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strconv"
 	"sync"
 	"sync/atomic"
 
@@ -391,6 +393,22 @@ func (n *Node) setState(state cluster.State) error {
 	return n.doRefreshServiceInstances()
 }
 
+// 更新负载，负载将发布到服务实例元数据中供最小负载分发策略使用
+func (n *Node) setLoad(load int) error {
+	n.rw.Lock()
+	metadata := make(map[string]string, len(n.opts.metadata)+1)
+	maps.Copy(metadata, n.opts.metadata)
+	metadata[cluster.LoadMetadataKey] = strconv.Itoa(load)
+	n.opts.metadata = metadata
+
+	for _, instance := range n.instances {
+		instance.Metadata = metadata
+	}
+	n.rw.Unlock()
+
+	return n.doRegisterServiceInstances()
+}
+
 // 执行钩子函数
 func (n *Node) runHookFunc(hook cluster.Hook) {
 	n.rw.RLock()
@@ -5,6 +5,7 @@ import (
 	"maps"
 	"time"
 
+	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/crypto"
 	"github.com/dobyte/due/v2/encoding"
 	"github.com/dobyte/due/v2/etc"
@@ -16,11 +17,13 @@ import (
 )
 
 const (
-	defaultName    = "node"          // 默认节点名称
-	defaultAddr    = ":0"            // 连接器监听地址
-	defaultCodec   = "proto"         // 默认编解码器名称
-	defaultTimeout = 3 * time.Second // 默认超时时间
-	defaultWeight  = 1               // 默认权重
+	defaultName     = "node"          // 默认节点名称
+	defaultAddr     = ":0"            // 连接器监听地址
+	defaultCodec    = "proto"         // 默认编解码器名称
+	defaultTimeout  = 3 * time.Second // 默认超时时间
+	defaultDispatch = cluster.Random  // 默认的无状态路由分发策略
+	defaultWeight   = 1               // 默认权重
+	defaultDormancy = 24 * time.Hour  // 默认的钝化记录保留时长
 )
 
 const (
@@ -31,7 +34,9 @@ const (
 	defaultCodecKey    = "etc.cluster.node.codec"
 	defaultWeightKey   = "etc.cluster.node.weight"
 	defaultTimeoutKey  = "etc.cluster.node.timeout"
+	defaultDispatchKey = "etc.cluster.node.dispatch"
 	defaultMetadataKey = "etc.cluster.node.metadata"
+	defaultDormancyKey = "etc.cluster.node.dormancy"
 )
 
 // SchedulingModel 调度模型
@@ -40,19 +45,23 @@ type SchedulingModel string
 type Option func(o *options)
 
 type options struct {
-	ctx         context.Context       // 上下文
-	id          string                // 实例ID
-	name        string                // 实例名称；相同实例名称的节点，用户只能绑定其中一个
-	addr        string                // 监听地址
-	expose      bool                  // 是否将内部通信地址暴露到公网
-	codec       encoding.Codec        // 编解码器
-	weight      int                   // 服务器权重
-	timeout     time.Duration         // RPC调用超时时间
-	locator     locate.Locator        // 用户定位器
-	registry    registry.Registry     // 服务注册器
-	encryptor   crypto.Encryptor      // 消息加密器
-	transporter transport.Transporter // 消息传输器
-	metadata    map[string]string     // 元数据
+	ctx              context.Context       // 上下文
+	id               string                // 实例ID
+	name             string                // 实例名称；相同实例名称的节点，用户只能绑定其中一个
+	addr             string                // 监听地址
+	expose           bool                  // 是否将内部通信地址暴露到公网
+	codec            encoding.Codec        // 编解码器
+	weight           int                   // 服务器权重
+	timeout          time.Duration         // RPC调用超时时间
+	dispatch         cluster.Dispatch      // 无状态路由消息分发策略
+	locator          locate.Locator        // 用户定位器
+	registry         registry.Registry     // 服务注册器
+	encryptor        crypto.Encryptor      // 消息加密器
+	transporter      transport.Transporter // 消息传输器
+	metadata         map[string]string     // 元数据
+	store            SnapshotStore         // Actor快照存储器
+	codeReplyHandler CodeReplyHandler      // 错误码回复处理器
+	dormancy         time.Duration         // Actor钝化记录保留时长
 }
 
 func defaultOptions() *options {
@@ -63,8 +72,10 @@ func defaultOptions() *options {
 		codec:    encoding.Invoke(defaultCodec),
 		weight:   defaultWeight,
 		timeout:  defaultTimeout,
+		dispatch: defaultDispatch,
 		metadata: make(map[string]string),
 		expose:   etc.Get(defaultExposeKey).Bool(),
+		dormancy: etc.Get(defaultDormancyKey, defaultDormancy).Duration(),
 	}
 
 	if id := etc.Get(defaultIDKey).String(); id != "" {
@@ -89,6 +100,10 @@ func defaultOptions() *options {
 		opts.timeout = timeout
 	}
 
+	if strategy := etc.Get(defaultDispatchKey).String(); strategy != "" {
+		opts.dispatch = cluster.Dispatch(strategy)
+	}
+
 	if weight := etc.Get(defaultWeightKey).Int(); weight > 0 {
 		opts.weight = weight
 	}
@@ -135,6 +150,11 @@ func WithTimeout(timeout time.Duration) Option {
 	return func(o *options) { o.timeout = timeout }
 }
 
+// WithDispatch 设置无状态路由消息分发策略
+func WithDispatch(dispatch cluster.Dispatch) Option {
+	return func(o *options) { o.dispatch = dispatch }
+}
+
 // WithLocator 设置定位器
 func WithLocator(locator locate.Locator) Option {
 	return func(o *options) { o.locator = locator }
@@ -164,3 +184,19 @@ func WithWeight(weight int) Option {
 func WithMetadata(metadata map[string]string) Option {
 	return func(o *options) { maps.Copy(o.metadata, metadata) }
 }
+
+// WithSnapshotStore 设置Actor快照存储器
+func WithSnapshotStore(store SnapshotStore) Option {
+	return func(o *options) { o.store = store }
+}
+
+// WithCodeReplyHandler 设置错误码回复处理器
+func WithCodeReplyHandler(handler CodeReplyHandler) Option {
+	return func(o *options) { o.codeReplyHandler = handler }
+}
+
+// WithDormancy 设置Actor钝化记录保留时长
+// Actor钝化后会为绑定的用户保留钝化记录，用户在保留时长内再次请求时将重新激活并绑定Actor；超过保留时长的记录将被清理，为0时永久保留
+func WithDormancy(dormancy time.Duration) Option {
+	return func(o *options) { o.dormancy = dormancy }
+}
@@ -0,0 +1,213 @@
+package node
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/utils/xcall"
+)
+
+type creatorEntity struct {
+	creator Creator       // 处理器创建器
+	opts    []ActorOption // Actor配置项
+}
+
+type dormant struct {
+	id     string    // Actor编号
+	expire time.Time // 过期时间，为零值时永不过期
+}
+
+// 检测钝化记录是否已过期
+func (d *dormant) expired(now time.Time) bool {
+	return !d.expire.IsZero() && !now.Before(d.expire)
+}
+
+// 启动钝化检测
+func (a *Actor) passivate() {
+	if a.opts.passivation <= 0 {
+		return
+	}
+
+	a.afterInvoke(a.opts.passivation, a.checkIdle, true)
+}
+
+// 检测Actor是否空闲，空闲时长超过钝化时长时对Actor进行钝化
+func (a *Actor) checkIdle() {
+	idle := time.Since(time.Unix(0, a.active.Load()))
+
+	if idle < a.opts.passivation {
+		a.afterInvoke(a.opts.passivation-idle, a.checkIdle, true)
+		return
+	}
+
+	// 销毁Actor时需等待处理协程消费邮箱，因此在独立协程中执行钝化
+	xcall.Go(func() { a.scheduler.passivate(a) })
+}
+
+// 注册Actor创建器
+func (s *Scheduler) registerCreator(kind string, creator Creator, opts ...ActorOption) {
+	s.creators.Store(kind, &creatorEntity{creator: creator, opts: opts})
+}
+
+// 钝化Actor，记录绑定的用户后销毁Actor
+// 在Actor的写锁内复核空闲状态，复核期间不会有新的消息投递到邮箱；存在待处理的消息或调用函数时放弃钝化并重新启动钝化检测
+// 钝化后仍投递到该Actor的消息将被转发到重新激活的Actor中
+func (s *Scheduler) passivate(act *Actor) {
+	now := time.Now()
+
+	act.rw.Lock()
+
+	if !act.isIdle(now) || !act.state.CompareAndSwap(started, destroyed) {
+		act.rw.Unlock()
+		act.passivate()
+		return
+	}
+
+	act.passivated.Store(true)
+
+	record := &dormant{id: act.ID()}
+
+	if ttl := s.node.opts.dormancy; ttl > 0 {
+		record.expire = now.Add(ttl)
+	}
+
+	s.rw.Lock()
+	s.sweepDormants(now)
+
+	act.binds.Range(func(uid, _ any) bool {
+		dormants, ok := s.dormants[uid.(int64)]
+		if !ok {
+			dormants = make(map[string]*dormant)
+			s.dormants[uid.(int64)] = dormants
+		}
+
+		dormants[act.Kind()] = record
+
+		return true
+	})
+	s.rw.Unlock()
+
+	s.remove(act.Kind(), act.ID())
+
+	act.rw.Unlock()
+
+	act.doDestroy()
+
+	if act.opts.wait {
+		s.node.doneWait()
+	}
+}
+
+// 检测Actor是否空闲，需持有Actor的写锁
+func (a *Actor) isIdle(now time.Time) bool {
+	if len(a.mailbox) > 0 || len(a.fnChan) > 0 {
+		return false
+	}
+
+	return now.Sub(time.Unix(0, a.active.Load())) >= a.opts.passivation
+}
+
+// 清理过期的钝化记录，每个保留时长内最多执行一次
+func (s *Scheduler) sweepDormants(now time.Time) {
+	ttl := s.node.opts.dormancy
+	if ttl <= 0 || now.Sub(s.sweepAt) < ttl {
+		return
+	}
+
+	for uid, dormants := range s.dormants {
+		for kind, record := range dormants {
+			if record.expired(now) {
+				delete(dormants, kind)
+			}
+		}
+
+		if len(dormants) == 0 {
+			delete(s.dormants, uid)
+		}
+	}
+
+	s.sweepAt = now
+}
+
+// 激活Actor，Actor不存在时通过已注册的创建器重新衍生
+func (s *Scheduler) activate(kind, id string) (*Actor, bool) {
+	if act, ok := s.load(kind, id); ok {
+		return act, true
+	}
+
+	v, ok := s.creators.Load(kind)
+	if !ok {
+		return nil, false
+	}
+
+	entity := v.(*creatorEntity)
+
+	opts := make([]ActorOption, 0, len(entity.opts)+2)
+	opts = append(opts, entity.opts...)
+	opts = append(opts, WithActorKind(kind), WithActorID(id))
+
+	act, err := s.spawn(entity.creator, opts...)
+	if err != nil {
+		if errors.Is(err, errors.ErrActorExists) {
+			return s.load(kind, id)
+		}
+
+		log.Errorf("activate actor failed, kind = %v id = %v err = %v", kind, id, err)
+		return nil, false
+	}
+
+	return act, true
+}
+
+// 通过PID激活Actor
+func (s *Scheduler) activateByPID(pid string) (*Actor, bool) {
+	if act, ok := s.doLoad(pid); ok {
+		return act, true
+	}
+
+	kind, id, ok := strings.Cut(pid, "/")
+	if !ok {
+		return nil, false
+	}
+
+	return s.activate(kind, id)
+}
+
+// 获取用户绑定的Actor，绑定的Actor已被钝化时重新激活并绑定
+func (s *Scheduler) loadOrActivateActor(uid int64, kind string) (*Actor, bool) {
+	if act, ok := s.loadActor(uid, kind); ok {
+		return act, true
+	}
+
+	s.rw.RLock()
+	record, ok := s.dormants[uid][kind]
+	s.rw.RUnlock()
+
+	if !ok || record.expired(time.Now()) {
+		return nil, false
+	}
+
+	act, ok := s.activate(kind, record.id)
+	if !ok {
+		return nil, false
+	}
+
+	if err := s.bindActor(uid, kind, record.id); err != nil {
+		return nil, false
+	}
+
+	return act, true
+}
+
+// 删除用户钝化的Actor记录
+func (s *Scheduler) deleteDormant(uid int64, kind string) {
+	if dormants, ok := s.dormants[uid]; ok {
+		delete(dormants, kind)
+
+		if len(dormants) == 0 {
+			delete(s.dormants, uid)
+		}
+	}
+}
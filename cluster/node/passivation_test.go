@@ -0,0 +1,177 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type passiveProcessor struct {
+	BaseProcessor
+	actor   *Actor
+	handled chan *Actor
+}
+
+func (p *passiveProcessor) Init() {
+	p.actor.AddRouteHandler(1, func(ctx Context) {
+		p.handled <- p.actor
+	})
+}
+
+func newPassiveScheduler(t *testing.T, opts ...Option) (*Scheduler, chan *Actor) {
+	t.Helper()
+
+	handled := make(chan *Actor, 1)
+
+	n := NewNode(opts...)
+	n.proxy.RegisterActorCreator("passive", func(actor *Actor, _ ...any) Processor {
+		return &passiveProcessor{actor: actor, handled: handled}
+	})
+
+	return n.scheduler, handled
+}
+
+// 投递消息到Actor
+func deliverTo(t *testing.T, act *Actor) {
+	t.Helper()
+
+	req := act.scheduler.node.reqPool.Get().(*request)
+	req.ctx = context.Background()
+	req.uid = 1
+	req.message.Route = 1
+
+	act.Next(req)
+}
+
+// 衍生Actor并绑定用户后将其钝化
+func passivateActor(t *testing.T, s *Scheduler, uid int64, id string) {
+	t.Helper()
+
+	act, ok := s.activate("passive", id)
+	if !ok {
+		t.Fatalf("activate actor %s failed", id)
+	}
+
+	if err := s.bindActor(uid, "passive", id); err != nil {
+		t.Fatal(err)
+	}
+
+	s.passivate(act)
+
+	if _, ok = s.load("passive", id); ok {
+		t.Fatalf("actor %s is not passivated", id)
+	}
+}
+
+func TestPassivate(t *testing.T) {
+	s, _ := newPassiveScheduler(t)
+
+	passivateActor(t, s, 1, "1")
+
+	act, ok := s.loadOrActivateActor(1, "passive")
+	if !ok || act.ID() != "1" {
+		t.Fatal("passivated actor is not reactivated")
+	}
+
+	if _, ok = s.dormants[1]; ok {
+		t.Fatal("dormant record should be deleted after reactivation")
+	}
+
+	if bound, ok := s.loadActor(1, "passive"); !ok || bound != act {
+		t.Fatal("reactivated actor is not bound to user")
+	}
+}
+
+func TestPassivate_Dormancy(t *testing.T) {
+	const dormancy = 20 * time.Millisecond
+
+	s, _ := newPassiveScheduler(t, WithDormancy(dormancy))
+
+	passivateActor(t, s, 1, "1")
+
+	time.Sleep(dormancy)
+
+	if _, ok := s.loadOrActivateActor(1, "passive"); ok {
+		t.Fatal("expired dormant record should not reactivate actor")
+	}
+
+	// 后续钝化时清理过期的钝化记录
+	passivateActor(t, s, 2, "2")
+
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	if _, ok := s.dormants[1]; ok {
+		t.Fatal("expired dormant record is not swept")
+	}
+
+	if _, ok := s.dormants[2]; !ok {
+		t.Fatal("dormant record of user 2 is lost")
+	}
+}
+
+func TestPassivate_Busy(t *testing.T) {
+	s, handled := newPassiveScheduler(t)
+
+	act, ok := s.activate("passive", "1")
+	if !ok {
+		t.Fatal("activate actor failed")
+	}
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+
+	act.Invoke(func() {
+		close(blocked)
+		<-release
+	})
+
+	<-blocked
+
+	// 空闲检测后、钝化前投递的消息将使钝化被放弃
+	deliverTo(t, act)
+
+	s.passivate(act)
+
+	if loaded, ok := s.load("passive", "1"); !ok || loaded != act {
+		t.Fatal("busy actor should not be passivated")
+	}
+
+	close(release)
+
+	select {
+	case got := <-handled:
+		if got != act {
+			t.Fatal("message is not handled by the original actor")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message is lost after passivation aborted")
+	}
+}
+
+func TestPassivate_Forward(t *testing.T) {
+	s, handled := newPassiveScheduler(t)
+
+	act, ok := s.activate("passive", "1")
+	if !ok {
+		t.Fatal("activate actor failed")
+	}
+
+	s.passivate(act)
+
+	// 钝化前已获取到旧Actor的消息将被转发到重新激活的Actor
+	deliverTo(t, act)
+
+	select {
+	case got := <-handled:
+		if got == act {
+			t.Fatal("message is handled by the passivated actor")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message delivered to passivated actor is lost")
+	}
+
+	if _, ok = s.load("passive", "1"); !ok {
+		t.Fatal("actor is not reactivated")
+	}
+}
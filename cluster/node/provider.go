@@ -5,6 +5,7 @@ import (
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/packet"
+	"github.com/dobyte/due/v2/trace"
 )
 
 type provider struct {
@@ -13,7 +14,7 @@ type provider struct {
 
 // Trigger 触发事件
 func (p *provider) Trigger(ctx context.Context, gid string, cid, uid int64, event cluster.Event) error {
-	p.node.trigger.trigger(event, gid, cid, uid)
+	p.node.trigger.trigger(trace.Detach(ctx), event, gid, cid, uid)
 
 	return nil
 }
@@ -47,7 +48,26 @@ func (p *provider) Deliver(ctx context.Context, gid, nid string, cid, uid int64,
 		}
 	}
 
-	p.node.router.deliver(gid, nid, "", cid, uid, msg.Seq, msg.Route, msg.Buffer)
+	deadline, _ := ctx.Deadline()
+
+	p.node.router.deliver(trace.Detach(ctx), gid, nid, "", cid, uid, msg.Seq, msg.Route, msg.Buffer, msg.Compressed, msg.Headers, deadline)
+
+	return nil
+}
+
+// Tell 投递Actor消息
+func (p *provider) Tell(ctx context.Context, nid string, cid, uid int64, target, source string, message []byte) error {
+	act, ok := p.node.scheduler.activateByPID(target)
+	if !ok {
+		return errors.ErrNotFoundActor
+	}
+
+	msg, err := packet.UnpackMessage(message)
+	if err != nil {
+		return err
+	}
+
+	act.deliver(nid, source, cid, uid, msg.Seq, msg.Route, msg.Buffer, msg.Headers)
 
 	return nil
 }
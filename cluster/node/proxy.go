@@ -27,6 +27,7 @@ func newProxy(node *Node) *Proxy {
 		Locator:   node.opts.locator,
 		Registry:  node.opts.registry,
 		Encryptor: node.opts.encryptor,
+		Dispatch:  node.opts.dispatch,
 	}
 
 	return &Proxy{
@@ -56,6 +57,12 @@ func (p *Proxy) SetState(state cluster.State) error {
 	return p.node.setState(state)
 }
 
+// SetLoad 设置当前节点负载
+// 负载值将发布到服务实例元数据中，分发策略为最小负载（cluster.LeastLoad）时，无状态路由消息将优先分发到负载最低的节点
+func (p *Proxy) SetLoad(load int) error {
+	return p.node.setLoad(load)
+}
+
 // Router 路由器
 func (p *Proxy) Router() *Router {
 	return p.node.router
@@ -241,6 +248,16 @@ func (p *Proxy) Stat(ctx context.Context, kind session.Kind) (int64, error) {
 	return p.gateLinker.Stat(ctx, kind)
 }
 
+// StatChannel 统计频道订阅者数量，订阅者包含订阅了匹配该频道的通配符频道的连接
+func (p *Proxy) StatChannel(ctx context.Context, channel string) (int64, error) {
+	return p.gateLinker.StatChannel(ctx, channel)
+}
+
+// GetChannels 获取订阅的频道
+func (p *Proxy) GetChannels(ctx context.Context, args *cluster.GetChannelsArgs) ([]string, error) {
+	return p.gateLinker.GetChannels(ctx, args)
+}
+
 // IsOnline 检测是否在线
 func (p *Proxy) IsOnline(ctx context.Context, args *cluster.IsOnlineArgs) (bool, error) {
 	return p.gateLinker.IsOnline(ctx, args)
@@ -295,6 +312,54 @@ func (p *Proxy) Deliver(ctx context.Context, args *cluster.DeliverArgs) error {
 	})
 }
 
+// Tell 投递消息给集群中的Actor进行处理
+// Actor位于当前节点时直接投递到Actor的邮箱中；否则通过定位器定位Actor所在节点后进行投递
+func (p *Proxy) Tell(ctx context.Context, args *cluster.TellArgs) error {
+	return p.doTell(ctx, args, "")
+}
+
+// LocateActor 定位Actor所在节点
+func (p *Proxy) LocateActor(ctx context.Context, kind, id string) (string, error) {
+	if _, ok := p.node.scheduler.load(kind, id); ok {
+		return p.node.opts.id, nil
+	}
+
+	return p.nodeLinker.LocateActor(ctx, kind, id)
+}
+
+// 执行投递消息给Actor
+func (p *Proxy) doTell(ctx context.Context, args *cluster.TellArgs, source string) error {
+	if args.NID == "" || args.NID == p.node.opts.id {
+		act, ok := p.node.scheduler.doLoad(args.PID)
+		if !ok && args.NID != "" {
+			act, ok = p.node.scheduler.activateByPID(args.PID)
+		}
+
+		if ok {
+			buf, err := p.gateLinker.PackBuffer(args.Message.Data, false)
+			if err != nil {
+				return err
+			}
+
+			act.deliver(p.node.opts.id, source, 0, args.UID, args.Message.Seq, args.Message.Route, buf, args.Message.Headers)
+
+			return nil
+		}
+
+		if args.NID != "" {
+			return errors.ErrNotFoundActor
+		}
+	}
+
+	return p.nodeLinker.Tell(ctx, &link.TellArgs{
+		NID:     args.NID,
+		UID:     args.UID,
+		Target:  args.PID,
+		Source:  source,
+		Message: args.Message,
+	})
+}
+
 // Invoke 调用函数（线程安全）
 func (p *Proxy) Invoke(fn func()) {
 	p.node.addWait()
@@ -330,6 +395,18 @@ func (p *Proxy) Spawn(creator Creator, opts ...ActorOption) (*Actor, error) {
 	return p.node.scheduler.spawn(creator, opts...)
 }
 
+// RegisterActorCreator 注册Actor创建器
+// 注册后，路由到该类型下不存在的Actor（如已被钝化的Actor）的请求会通过此创建器重新衍生Actor
+func (p *Proxy) RegisterActorCreator(kind string, creator Creator, opts ...ActorOption) {
+	p.node.scheduler.registerCreator(kind, creator, opts...)
+}
+
+// SetActorRouting 设置Actor类型的路由策略
+// 设置后，路由到该类型但未绑定Actor的用户请求会根据路由策略分发到该类型下的某个可调度的Actor
+func (p *Proxy) SetActorRouting(kind string, routing Routing) {
+	p.node.scheduler.setRouting(kind, routing)
+}
+
 // Kill 杀死存在的一个Actor
 func (p *Proxy) Kill(kind, id string) bool {
 	return p.node.scheduler.kill(kind, id)
@@ -0,0 +1,47 @@
+package node
+
+import (
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/log"
+)
+
+// CodeReplyHandler 错误码回复处理器
+// 请求因邮箱溢出、限流、超时等原因无法被正常处理时，通过此处理器将错误码回复给客户端
+type CodeReplyHandler func(ctx Context, code *codes.Code) error
+
+// CodeReply 默认的错误码回复消息
+type CodeReply = cluster.CodeReply
+
+// 默认的错误码回复处理器
+// 通过节点的编解码器与加密器编码错误码回复消息，proto编解码器下无需额外定义消息结构
+func (n *Node) defaultCodeReplyHandler(ctx Context, code *codes.Code) error {
+	buf, err := cluster.MarshalCodeReply(n.opts.codec, code)
+	if err != nil {
+		return err
+	}
+
+	if n.opts.encryptor != nil {
+		if buf, err = n.opts.encryptor.Encrypt(buf); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Response(buf)
+}
+
+// 回复错误码
+func (n *Node) replyCode(ctx Context, code *codes.Code) {
+	if ctx.Kind() != Request {
+		return
+	}
+
+	handler := n.opts.codeReplyHandler
+	if handler == nil {
+		handler = n.defaultCodeReplyHandler
+	}
+
+	if err := handler(ctx, code); err != nil {
+		log.Warnf("reply code failed, uid = %v route = %v code = %v err = %v", ctx.UID(), ctx.Route(), code.Code(), err)
+	}
+}
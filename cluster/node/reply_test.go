@@ -0,0 +1,47 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/encoding/proto"
+)
+
+type responseRecorder struct {
+	*request
+	message any
+}
+
+func (r *responseRecorder) Response(message any) error {
+	r.message = message
+	return nil
+}
+
+func TestDefaultCodeReplyHandler(t *testing.T) {
+	n := NewNode()
+
+	if n.opts.codec.Name() != proto.Name {
+		t.Fatalf("default codec mismatch, want: %s got: %s", proto.Name, n.opts.codec.Name())
+	}
+
+	ctx := &responseRecorder{request: &request{}}
+
+	if err := n.defaultCodeReplyHandler(ctx, codes.TooManyRequests); err != nil {
+		t.Fatal(err)
+	}
+
+	data, ok := ctx.message.([]byte)
+	if !ok {
+		t.Fatalf("code reply is not encoded, message: %v", ctx.message)
+	}
+
+	reply, err := cluster.UnmarshalCodeReply(n.opts.codec, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.Code != codes.TooManyRequests.Code() || reply.Message != codes.TooManyRequests.Message() {
+		t.Fatalf("code reply mismatch, got: %+v", reply)
+	}
+}
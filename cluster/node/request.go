@@ -15,6 +15,8 @@ import (
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/core/chains"
 	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/packet"
 	"github.com/dobyte/due/v2/session"
 	"github.com/dobyte/due/v2/task"
 	"github.com/dobyte/due/v2/transport"
@@ -23,17 +25,21 @@ import (
 )
 
 type request struct {
-	node    *Node
-	ctx     context.Context  // 上下文
-	gid     string           // 来源网关ID
-	nid     string           // 来源节点ID
-	pid     string           // 来源Actor ID
-	cid     int64            // 连接ID
-	uid     int64            // 用户ID
-	message *cluster.Message // 请求消息
-	version atomic.Int32     // 版本号
-	chain   *chains.Chain    // 调用链
-	actor   atomic.Value     // 当前Actor
+	node       *Node
+	ctx        context.Context   // 上下文
+	gid        string            // 来源网关ID
+	nid        string            // 来源节点ID
+	pid        string            // 来源Actor ID
+	cid        int64             // 连接ID
+	uid        int64             // 用户ID
+	message    *cluster.Message  // 请求消息
+	compressed bool              // 消息内容是否为先压缩再加密的数据
+	version    atomic.Int32      // 版本号
+	chain      *chains.Chain     // 调用链
+	actor      atomic.Value      // 当前Actor
+	deadline   time.Time         // 截止时间
+	expiry     *expiry           // 超时控制
+	headers    map[string]string // 回复消息扩展头
 }
 
 // GID 获取网关ID
@@ -76,6 +82,25 @@ func (r *request) Kind() Kind {
 	return Request
 }
 
+// Header 获取消息扩展头
+func (r *request) Header(key string) string {
+	return r.message.Headers[key]
+}
+
+// Headers 获取消息的所有扩展头
+func (r *request) Headers() map[string]string {
+	return r.message.Headers
+}
+
+// SetReplyHeader 设置回复消息的扩展头
+func (r *request) SetReplyHeader(key, value string) {
+	if r.headers == nil {
+		r.headers = make(map[string]string)
+	}
+
+	r.headers[key] = value
+}
+
 // Parse 解析消息
 func (r *request) Parse(v any) error {
 	msg, ok := r.message.Data.([]byte)
@@ -95,6 +120,12 @@ func (r *request) Parse(v any) error {
 			return err
 		}
 
+		if r.compressed {
+			if data, err = packet.DecompressBuffer(data); err != nil {
+				return err
+			}
+		}
+
 		return r.node.opts.codec.Unmarshal(data, v)
 	}
 
@@ -136,19 +167,28 @@ func (r *request) compareVersionExecDefer(version int32) {
 // Clone 克隆Context
 func (r *request) Clone() Context {
 	c := &request{
-		node: r.node,
-		gid:  r.gid,
-		nid:  r.nid,
-		cid:  r.cid,
-		uid:  r.uid,
-		ctx:  context.Background(),
+		node:       r.node,
+		gid:        r.gid,
+		nid:        r.nid,
+		cid:        r.cid,
+		uid:        r.uid,
+		ctx:        context.Background(),
+		compressed: r.compressed,
 		message: &cluster.Message{
-			Seq:   r.message.Seq,
-			Route: r.message.Route,
-			Data:  r.message.Data,
+			Seq:     r.message.Seq,
+			Route:   r.message.Route,
+			Data:    r.message.Data,
+			Headers: r.message.Headers,
 		},
 	}
 
+	if len(r.headers) > 0 {
+		c.headers = make(map[string]string, len(r.headers))
+		for key, value := range r.headers {
+			c.headers[key] = value
+		}
+	}
+
 	c.actor.Store(r.actor.Load())
 
 	return c
@@ -203,6 +243,25 @@ func (r *request) GetValue(key any) any {
 	return r.ctx.Value(key)
 }
 
+// Logger 获取携带请求字段的日志记录器
+func (r *request) Logger() log.Logger {
+	fields := make([]log.Field, 0, 8)
+	fields = append(fields, log.Any("gid", r.gid))
+
+	if r.nid != "" {
+		fields = append(fields, log.Any("nid", r.nid))
+	}
+
+	fields = append(fields,
+		log.Any("cid", r.cid),
+		log.Any("uid", r.uid),
+		log.Any("route", r.message.Route),
+		log.Any("seq", r.message.Seq),
+	)
+
+	return newContextLogger(r.ctx, fields...)
+}
+
 // BindGate 绑定网关
 func (r *request) BindGate(uid ...int64) error {
 	switch {
@@ -379,6 +438,14 @@ func (r *request) Deliver(args *cluster.DeliverArgs) error {
 
 // Reply 回复消息
 func (r *request) Reply(message *cluster.Message) error {
+	if !r.claim() {
+		return errors.ErrDeadlineExceeded
+	}
+
+	if len(r.headers) > 0 {
+		message = r.withReplyHeaders(message)
+	}
+
 	switch {
 	case r.gid != "": // 来源于网关
 		return r.node.proxy.Push(r.ctx, &cluster.PushArgs{
@@ -388,8 +455,31 @@ func (r *request) Reply(message *cluster.Message) error {
 			Message: message,
 		})
 	case r.pid != "": // 来源于Actor
+		if r.message.Headers[askHeader] == askRequest {
+			message = &cluster.Message{
+				Seq:     message.Seq,
+				Route:   message.Route,
+				Data:    message.Data,
+				Headers: withAskHeader(message.Headers, askReply),
+			}
+		}
+
+		if r.nid != "" && r.nid != r.node.opts.id {
+			return r.node.proxy.Tell(r.ctx, &cluster.TellArgs{
+				NID:     r.nid,
+				PID:     r.pid,
+				UID:     r.uid,
+				Message: message,
+			})
+		}
+
 		if actor, ok := r.node.scheduler.doLoad(r.pid); ok {
-			return actor.Deliver(r.uid, message)
+			buf, err := r.node.proxy.gateLinker.PackBuffer(message.Data, false)
+			if err != nil {
+				return err
+			}
+
+			actor.deliver(r.node.opts.id, "", 0, r.uid, message.Seq, message.Route, buf, message.Headers)
 		}
 
 		return nil
@@ -408,6 +498,25 @@ func (r *request) Reply(message *cluster.Message) error {
 	}
 }
 
+// 为回复消息附加扩展头，回复消息自身携带的扩展头优先
+func (r *request) withReplyHeaders(message *cluster.Message) *cluster.Message {
+	headers := make(map[string]string, len(r.headers)+len(message.Headers))
+	for key, value := range r.headers {
+		headers[key] = value
+	}
+
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+
+	return &cluster.Message{
+		Seq:     message.Seq,
+		Route:   message.Route,
+		Data:    message.Data,
+		Headers: headers,
+	}
+}
+
 // Response 响应消息
 func (r *request) Response(message any) error {
 	return r.Reply(&cluster.Message{
@@ -469,10 +578,26 @@ func (r *request) compareVersionRecycle(version int32) {
 
 // 重置请求对象
 func (r *request) reset() {
+	r.ctx = nil
+	r.gid = ""
+	r.nid = ""
+	r.pid = ""
+	r.cid = 0
+	r.uid = 0
+	r.compressed = false
 	r.message.Data = nil
+	r.message.Headers = nil
+	r.headers = nil
 
 	r.actor.Store((*Actor)(nil))
 
+	r.deadline = time.Time{}
+
+	if r.expiry != nil {
+		r.expiry.release()
+		r.expiry = nil
+	}
+
 	if r.chain != nil {
 		r.chain.Cancel()
 		r.chain = nil
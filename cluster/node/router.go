@@ -2,9 +2,11 @@ package node
 
 import (
 	"context"
+	"time"
 
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/trace"
 	"github.com/dobyte/due/v2/utils/xcall"
 )
 
@@ -23,6 +25,7 @@ type routeEntity struct {
 	route       int32               // 路由
 	stateful    bool                // 是否有状态
 	internal    bool                // 是否内部路由
+	timeout     time.Duration       // 处理超时时间
 	handler     RouteHandler        // 路由处理器
 	middlewares []MiddlewareHandler // 路由中间件
 }
@@ -44,6 +47,11 @@ type RouteOptions struct {
 	// 非受限路由不受节点状态影响
 	Restricted bool
 
+	// 路由处理超时时间，默认为0不限制
+	// 请求的截止时间取网关投递时携带的截止时间与此超时时间中较早的一个，可通过ctx.Context()获取
+	// 超过截止时间后ctx.Context()将被取消，并向客户端回复codes.DeadlineExceeded，此后处理器的回复将被丢弃
+	Timeout time.Duration
+
 	// 路由中间件
 	Middlewares []MiddlewareHandler
 }
@@ -87,6 +95,23 @@ func (r *Router) AddInternalRouteHandler(route int32, stateful bool, handler Rou
 	}
 }
 
+// AddRouteHandlerWithOptions 添加带选项的路由处理器
+func (r *Router) AddRouteHandlerWithOptions(route int32, handler RouteHandler, opts RouteOptions) {
+	if r.node.getState() != cluster.Shut {
+		log.Warnf("the node server is working, can't add route handler")
+		return
+	}
+
+	r.routes[route] = &routeEntity{
+		route:       route,
+		stateful:    opts.Stateful,
+		internal:    opts.Internal,
+		timeout:     opts.Timeout,
+		handler:     handler,
+		middlewares: opts.Middlewares[:],
+	}
+}
+
 // SetDefaultRouteHandler 设置默认路由处理器，所有未注册的路由均走默认路由处理器
 func (r *Router) SetDefaultRouteHandler(handler RouteHandler) {
 	if r.node.getState() != cluster.Shut {
@@ -144,9 +169,10 @@ func (r *Router) Group(groups ...func(group *RouterGroup)) *RouterGroup {
 	return group
 }
 
-func (r *Router) deliver(gid, nid, pid string, cid, uid int64, seq, route int32, data any) {
+func (r *Router) deliver(ctx context.Context, gid, nid, pid string, cid, uid int64, seq, route int32, data any, compressed bool, headers map[string]string, deadline time.Time) {
 	req := r.node.reqPool.Get().(*request)
-	req.ctx = context.Background()
+	req.ctx = ctx
+	req.deadline = deadline
 	req.gid = gid
 	req.nid = nid
 	req.pid = pid
@@ -155,6 +181,8 @@ func (r *Router) deliver(gid, nid, pid string, cid, uid int64, seq, route int32,
 	req.message.Seq = seq
 	req.message.Route = route
 	req.message.Data = data
+	req.message.Headers = headers
+	req.compressed = compressed
 	r.reqChan <- req
 }
 
@@ -176,6 +204,27 @@ func (r *Router) handle(req *request) {
 		return
 	}
 
+	var timeout time.Duration
+	if ok {
+		timeout = route.timeout
+	}
+
+	if !req.withDeadline(timeout) {
+		req.compareVersionRecycle(version)
+		log.Warnf("message handling deadline exceeded, uid: %v route: %v", req.uid, req.message.Route)
+		return
+	}
+
+	defer observeHandle(req.message.Route, ok, time.Now())
+
+	var span *trace.Span
+	req.ctx, span = trace.Start(req.ctx, "node.handle")
+	span.SetAttribute("route", req.message.Route)
+	span.SetAttribute("seq", req.message.Seq)
+	span.SetAttribute("cid", req.cid)
+	span.SetAttribute("uid", req.uid)
+	defer span.Finish()
+
 	if r.preRouteHandler != nil {
 		xcall.Call(func() { r.preRouteHandler(req) })
 	}
@@ -219,6 +268,17 @@ func (g *RouterGroup) AddRouteHandler(route int32, stateful bool, handler RouteH
 	return g
 }
 
+// AddRouteHandlerWithOptions 添加带选项的路由处理器
+func (g *RouterGroup) AddRouteHandlerWithOptions(route int32, handler RouteHandler, opts RouteOptions) *RouterGroup {
+	dst := make([]MiddlewareHandler, len(g.middlewares)+len(opts.Middlewares))
+	copy(dst, g.middlewares)
+	copy(dst[len(g.middlewares):], opts.Middlewares)
+	opts.Middlewares = dst
+	g.router.AddRouteHandlerWithOptions(route, handler, opts)
+
+	return g
+}
+
 // AddInternalRouteHandler 添加内部路由处理器（node节点间路由消息处理）
 func (g *RouterGroup) AddInternalRouteHandler(route int32, stateful bool, handler RouteHandler, middlewares ...MiddlewareHandler) *RouterGroup {
 	dst := make([]MiddlewareHandler, len(g.middlewares)+len(middlewares))
@@ -0,0 +1,143 @@
+package node
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"sync"
+
+	"github.com/dobyte/due/v2/core/consistent"
+	"github.com/dobyte/due/v2/errors"
+)
+
+// Routing Actor类型路由策略，用于将未绑定Actor的用户请求分发到该类型下的某个Actor
+type Routing int
+
+const (
+	RoutingNone           Routing = iota // 不进行路由，用户须通过BindActor绑定Actor
+	RoutingConsistentHash                // 根据UID进行一致性哈希
+	RoutingRandom                        // 随机
+	RoutingLeastLoaded                   // 邮箱中待处理消息最少
+)
+
+type actorGroup struct {
+	mu      sync.Mutex
+	routing Routing
+	actors  map[string]*Actor
+	list    []*Actor
+	ring    *consistent.Consistent
+	dirty   bool
+}
+
+func newActorGroup() *actorGroup {
+	return &actorGroup{actors: make(map[string]*Actor)}
+}
+
+// 添加Actor
+func (g *actorGroup) add(act *Actor) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.actors[act.ID()] = act
+	g.dirty = true
+}
+
+// 移除Actor
+func (g *actorGroup) remove(act *Actor) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if a, ok := g.actors[act.ID()]; ok && a == act {
+		delete(g.actors, act.ID())
+		g.dirty = true
+	}
+}
+
+// 选取Actor
+func (g *actorGroup) pick(uid int64) (*Actor, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.routing == RoutingNone {
+		if uid == 0 {
+			return nil, errors.ErrMissingDispatchStrategy
+		}
+
+		return nil, errors.ErrNotBindActor
+	}
+
+	if g.dirty {
+		g.rebuild()
+	}
+
+	if len(g.list) == 0 {
+		return nil, errors.ErrNotFoundActor
+	}
+
+	switch g.routing {
+	case RoutingConsistentHash:
+		if uid == 0 {
+			return nil, errors.ErrMissingDispatchStrategy
+		}
+
+		id, _ := g.ring.Get(strconv.FormatInt(uid, 10))
+
+		return g.actors[id], nil
+	case RoutingLeastLoaded:
+		selected := g.list[0]
+		for _, act := range g.list[1:] {
+			if len(act.mailbox) < len(selected.mailbox) {
+				selected = act
+			}
+		}
+
+		return selected, nil
+	default:
+		return g.list[rand.IntN(len(g.list))], nil
+	}
+}
+
+// 重建Actor列表与哈希环
+func (g *actorGroup) rebuild() {
+	g.list = g.list[:0]
+	ids := make([]string, 0, len(g.actors))
+
+	for id, act := range g.actors {
+		g.list = append(g.list, act)
+		ids = append(ids, id)
+	}
+
+	if g.routing == RoutingConsistentHash {
+		if g.ring == nil {
+			g.ring = consistent.NewConsistent()
+		}
+
+		g.ring.Replace(ids...)
+	}
+
+	g.dirty = false
+}
+
+// 设置路由策略
+func (g *actorGroup) setRouting(routing Routing) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.routing = routing
+	g.dirty = true
+}
+
+// 加载Actor分组
+func (s *Scheduler) loadGroup(kind string) *actorGroup {
+	if v, ok := s.groups.Load(kind); ok {
+		return v.(*actorGroup)
+	}
+
+	v, _ := s.groups.LoadOrStore(kind, newActorGroup())
+
+	return v.(*actorGroup)
+}
+
+// 设置Actor类型的路由策略
+func (s *Scheduler) setRouting(kind string, routing Routing) {
+	s.loadGroup(kind).setRouting(routing)
+}
@@ -0,0 +1,161 @@
+package node
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dobyte/due/v2/errors"
+)
+
+// 构建邮箱中有pending条待处理消息的Actor
+func newGroupActor(id string, pending int) *Actor {
+	act := &Actor{
+		opts:    &actorOptions{kind: "group", id: id},
+		mailbox: make(chan Context, 4),
+	}
+
+	for i := 0; i < pending; i++ {
+		act.mailbox <- nil
+	}
+
+	return act
+}
+
+func newActorGroupWith(routing Routing, actors ...*Actor) *actorGroup {
+	g := newActorGroup()
+	g.setRouting(routing)
+
+	for _, act := range actors {
+		g.add(act)
+	}
+
+	return g
+}
+
+func mustPick(t *testing.T, g *actorGroup, uid int64) *Actor {
+	t.Helper()
+
+	act, err := g.pick(uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return act
+}
+
+func TestActorGroup_RoutingNone(t *testing.T) {
+	g := newActorGroupWith(RoutingNone, newGroupActor("1", 0))
+
+	if _, err := g.pick(0); !errors.Is(err, errors.ErrMissingDispatchStrategy) {
+		t.Fatalf("pick without uid, want: %v got: %v", errors.ErrMissingDispatchStrategy, err)
+	}
+
+	if _, err := g.pick(1); !errors.Is(err, errors.ErrNotBindActor) {
+		t.Fatalf("pick with uid, want: %v got: %v", errors.ErrNotBindActor, err)
+	}
+}
+
+func TestActorGroup_Empty(t *testing.T) {
+	for _, routing := range []Routing{RoutingConsistentHash, RoutingRandom, RoutingLeastLoaded} {
+		act := newGroupActor("1", 0)
+		g := newActorGroupWith(routing, act)
+		g.remove(act)
+
+		if _, err := g.pick(1); !errors.Is(err, errors.ErrNotFoundActor) {
+			t.Fatalf("routing %d pick from empty group, want: %v got: %v", routing, errors.ErrNotFoundActor, err)
+		}
+	}
+}
+
+func TestActorGroup_ConsistentHash(t *testing.T) {
+	g := newActorGroupWith(RoutingConsistentHash, newGroupActor("1", 0), newGroupActor("2", 0), newGroupActor("3", 0))
+
+	if _, err := g.pick(0); !errors.Is(err, errors.ErrMissingDispatchStrategy) {
+		t.Fatalf("pick without uid, want: %v got: %v", errors.ErrMissingDispatchStrategy, err)
+	}
+
+	picked := make(map[int64]*Actor)
+	hit := make(map[string]struct{})
+
+	for uid := int64(1); uid <= 100; uid++ {
+		act := mustPick(t, g, uid)
+		picked[uid] = act
+		hit[act.ID()] = struct{}{}
+
+		if again := mustPick(t, g, uid); again != act {
+			t.Fatalf("uid %d is routed to different actors: %s and %s", uid, act.ID(), again.ID())
+		}
+	}
+
+	if len(hit) != 3 {
+		t.Fatalf("requests are not spread over all actors, hit: %d", len(hit))
+	}
+
+	// 移除Actor后，仅原本路由到该Actor的用户会被重新路由
+	removed := picked[1]
+	g.remove(removed)
+
+	for uid, act := range picked {
+		got := mustPick(t, g, uid)
+
+		if got == removed {
+			t.Fatalf("uid %d is routed to removed actor %s", uid, removed.ID())
+		}
+
+		if act != removed && got != act {
+			t.Fatalf("uid %d is remapped from %s to %s", uid, act.ID(), got.ID())
+		}
+	}
+}
+
+func TestActorGroup_Random(t *testing.T) {
+	actors := make(map[string]*Actor)
+	g := newActorGroupWith(RoutingRandom)
+
+	for i := 1; i <= 3; i++ {
+		act := newGroupActor(strconv.Itoa(i), 0)
+		actors[act.ID()] = act
+		g.add(act)
+	}
+
+	hit := make(map[string]struct{})
+
+	for i := 0; i < 200; i++ {
+		act := mustPick(t, g, 0)
+
+		if actors[act.ID()] != act {
+			t.Fatalf("picked actor %s is not in group", act.ID())
+		}
+
+		hit[act.ID()] = struct{}{}
+	}
+
+	if len(hit) != 3 {
+		t.Fatalf("requests are not spread over all actors, hit: %d", len(hit))
+	}
+}
+
+func TestActorGroup_LeastLoaded(t *testing.T) {
+	busy := newGroupActor("1", 3)
+	idle := newGroupActor("2", 0)
+	normal := newGroupActor("3", 1)
+
+	g := newActorGroupWith(RoutingLeastLoaded, busy, idle, normal)
+
+	if act := mustPick(t, g, 0); act != idle {
+		t.Fatalf("least loaded actor mismatch, want: %s got: %s", idle.ID(), act.ID())
+	}
+
+	// 移除的Actor与分组中的Actor不一致时不进行移除
+	g.remove(newGroupActor("2", 0))
+
+	if act := mustPick(t, g, 0); act != idle {
+		t.Fatalf("actor is removed by a stale reference, got: %s", act.ID())
+	}
+
+	g.remove(idle)
+
+	if act := mustPick(t, g, 0); act != normal {
+		t.Fatalf("least loaded actor mismatch, want: %s got: %s", normal.ID(), act.ID())
+	}
+}
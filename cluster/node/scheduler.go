@@ -1,10 +1,12 @@
 package node
 
 import (
+	"sync"
+	"time"
+
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/log"
-	"sync"
 )
 
 type Scheduler struct {
@@ -13,20 +15,27 @@ type Scheduler struct {
 	actors    sync.Map
 	routes    sync.Map
 	kinds     sync.Map
+	creators  sync.Map
+	groups    sync.Map
 	rw        sync.RWMutex
 	relations map[int64]map[string]*Actor
+	dormants  map[int64]map[string]*dormant
+	sweepAt   time.Time
 }
 
 func newScheduler(node *Node) *Scheduler {
 	return &Scheduler{
 		node:      node,
 		relations: make(map[int64]map[string]*Actor),
+		dormants:  make(map[int64]map[string]*dormant),
+		sweepAt:   time.Now(),
 	}
 }
 
 // 衍生出一个Actor
 func (s *Scheduler) spawn(creator Creator, opts ...ActorOption) (*Actor, error) {
 	o := defaultActorOptions()
+	o.store = s.node.opts.store
 	for _, opt := range opts {
 		opt(o)
 	}
@@ -37,8 +46,10 @@ func (s *Scheduler) spawn(creator Creator, opts ...ActorOption) (*Actor, error)
 	act.state.Store(started)
 	act.routes = make(map[int32]RouteHandler)
 	act.events = make(map[cluster.Event]EventHandler, 3)
-	act.mailbox = make(chan Context, 4096)
-	act.fnChan = make(chan func(), 4096)
+	act.mailbox = make(chan Context, o.mailbox)
+	act.fnChan = make(chan func(), o.mailbox)
+	act.creator = creator
+	act.active.Store(time.Now().UnixNano())
 	act.processor = creator(act, o.args...)
 
 	s.mu.Lock()
@@ -52,7 +63,21 @@ func (s *Scheduler) spawn(creator Creator, opts ...ActorOption) (*Actor, error)
 		s.node.addWait()
 	}
 
-	act.processor.Init()
+	act.initProcessor()
+
+	if err := act.restore(); err != nil {
+		if act.opts.wait {
+			s.node.doneWait()
+		}
+
+		s.mu.Unlock()
+
+		// 处理器已完成初始化，恢复失败时需销毁处理器以释放其在初始化时申请的资源
+		act.state.Store(destroyed)
+		act.processor.Destroy()
+
+		return nil, err
+	}
 
 	if act.opts.dispatch {
 		if _, ok := s.kinds.Load(act.Kind()); !ok {
@@ -66,12 +91,26 @@ func (s *Scheduler) spawn(creator Creator, opts ...ActorOption) (*Actor, error)
 
 	s.actors.Store(act.PID(), act)
 
+	if act.opts.dispatch {
+		s.loadGroup(act.Kind()).add(act)
+	}
+
 	s.mu.Unlock()
 
+	if act.opts.addressable {
+		act.bindLocation()
+	}
+
 	go act.dispatch()
 
 	act.processor.Start()
 
+	act.report(ActorStarted, nil)
+
+	act.checkpoint()
+
+	act.passivate()
+
 	return act, nil
 }
 
@@ -103,6 +142,10 @@ func (s *Scheduler) remove(kind, id string) (*Actor, bool) {
 
 	s.actors.Delete(act.PID())
 
+	if act.opts.dispatch {
+		s.loadGroup(act.Kind()).remove(act)
+	}
+
 	for _, relations := range s.relations {
 		if a, ok := relations[act.Kind()]; ok && a == act {
 			delete(relations, act.Kind())
@@ -150,6 +193,8 @@ func (s *Scheduler) bindActor(uid int64, kind, id string) error {
 
 	relations[act.Kind()] = act
 
+	s.deleteDormant(uid, act.Kind())
+
 	return nil
 }
 
@@ -158,6 +203,8 @@ func (s *Scheduler) unbindActor(uid int64, kind string) {
 	s.rw.Lock()
 	defer s.rw.Unlock()
 
+	s.deleteDormant(uid, kind)
+
 	relations, ok := s.relations[uid]
 	if !ok {
 		return
@@ -206,22 +253,33 @@ func (s *Scheduler) dispatch(ctx Context) error {
 }
 
 // 分发请求
+// 优先分发到用户绑定的Actor，用户未绑定Actor时根据Actor类型的路由策略进行分发
 func (s *Scheduler) dispatchRequest(ctx Context) error {
 	uid := ctx.UID()
 
-	if uid == 0 {
-		return errors.ErrMissingDispatchStrategy
-	}
-
 	kind, ok := s.routes.Load(ctx.Route())
 	if !ok {
+		if uid == 0 {
+			return errors.ErrMissingDispatchStrategy
+		}
+
 		return errors.ErrUnregisterRoute
 	}
 
-	act, ok := s.loadActor(uid, kind.(string))
-	if !ok {
-		log.Errorf("dispatch request failed, uid = %v route = %v kind = %v", uid, ctx.Route(), kind)
-		return errors.ErrNotBindActor
+	if uid != 0 {
+		if act, ok := s.loadOrActivateActor(uid, kind.(string)); ok {
+			act.Next(ctx)
+			return nil
+		}
+	}
+
+	act, err := s.loadGroup(kind.(string)).pick(uid)
+	if err != nil {
+		if uid != 0 {
+			log.Errorf("dispatch request failed, uid = %v route = %v kind = %v", uid, ctx.Route(), kind)
+		}
+
+		return err
 	}
 
 	act.Next(ctx)
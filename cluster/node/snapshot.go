@@ -0,0 +1,204 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dobyte/due/v2/log"
+)
+
+// Persistent 可持久化的处理器
+// Processor实现此接口后，Actor会在衍生时从快照存储器中恢复状态，在销毁时以及定期检查点时写入快照
+type Persistent interface {
+	// Snapshot 生成状态快照
+	Snapshot() ([]byte, error)
+	// Restore 从快照中恢复状态
+	Restore(data []byte) error
+}
+
+// SnapshotStore 快照存储器
+type SnapshotStore interface {
+	// Load 加载快照，快照不存在时返回nil
+	Load(ctx context.Context, pid string) ([]byte, error)
+	// Save 保存快照
+	Save(ctx context.Context, pid string, data []byte) error
+	// Delete 删除快照
+	Delete(ctx context.Context, pid string) error
+}
+
+type memorySnapshotStore struct {
+	rw        sync.RWMutex
+	snapshots map[string][]byte
+}
+
+// NewMemorySnapshotStore 新建内存快照存储器
+func NewMemorySnapshotStore() SnapshotStore {
+	return &memorySnapshotStore{snapshots: make(map[string][]byte)}
+}
+
+// Load 加载快照
+func (s *memorySnapshotStore) Load(_ context.Context, pid string) ([]byte, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	data, ok := s.snapshots[pid]
+	if !ok {
+		return nil, nil
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+// Save 保存快照
+func (s *memorySnapshotStore) Save(_ context.Context, pid string, data []byte) error {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	s.snapshots[pid] = append([]byte(nil), data...)
+
+	return nil
+}
+
+// Delete 删除快照
+func (s *memorySnapshotStore) Delete(_ context.Context, pid string) error {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	delete(s.snapshots, pid)
+
+	return nil
+}
+
+type fileSnapshotStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileSnapshotStore 新建文件快照存储器
+func NewFileSnapshotStore(dir string) SnapshotStore {
+	return &fileSnapshotStore{dir: dir}
+}
+
+// Load 加载快照
+func (s *fileSnapshotStore) Load(_ context.Context, pid string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(pid))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Save 保存快照
+// 先写入临时文件再重命名，避免进程崩溃时产生损坏的快照
+func (s *fileSnapshotStore) Save(_ context.Context, pid string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	path := s.path(pid)
+	temp := path + ".tmp"
+
+	if err := os.WriteFile(temp, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temp, path)
+}
+
+// Delete 删除快照
+func (s *fileSnapshotStore) Delete(_ context.Context, pid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(pid)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// 获取快照文件路径
+func (s *fileSnapshotStore) path(pid string) string {
+	return filepath.Join(s.dir, strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(pid)+".snapshot")
+}
+
+// 获取可持久化的处理器
+// 检查点在分发协程中执行，而销毁可能在其他协程中置空处理器，因此需加锁读取
+func (a *Actor) persistent() (Persistent, bool) {
+	a.rw.RLock()
+	defer a.rw.RUnlock()
+
+	persistent, ok := a.processor.(Persistent)
+
+	return persistent, ok
+}
+
+// 恢复Actor状态
+func (a *Actor) restore() error {
+	persistent, ok := a.persistent()
+	if !ok || a.opts.store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(a.scheduler.node.ctx, defaultTimeout)
+	defer cancel()
+
+	data, err := a.opts.store.Load(ctx, a.PID())
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	return persistent.Restore(data)
+}
+
+// 保存Actor状态快照
+func (a *Actor) snapshot() error {
+	persistent, ok := a.persistent()
+	if !ok || a.opts.store == nil {
+		return nil
+	}
+
+	data, err := persistent.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	return a.opts.store.Save(ctx, a.PID(), data)
+}
+
+// 启动快照检查点
+func (a *Actor) checkpoint() {
+	if a.opts.checkpoint <= 0 || a.opts.store == nil {
+		return
+	}
+
+	if _, ok := a.persistent(); !ok {
+		return
+	}
+
+	a.afterInvoke(a.opts.checkpoint, func() {
+		if err := a.snapshot(); err != nil {
+			log.Errorf("actor checkpoint failed, pid = %v err = %v", a.PID(), err)
+		}
+
+		a.checkpoint()
+	}, true)
+}
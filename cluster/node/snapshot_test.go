@@ -0,0 +1,197 @@
+package node_test
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster/node"
+	"github.com/dobyte/due/v2/errors"
+)
+
+type persistentProcessor struct {
+	node.BaseProcessor
+	counter   atomic.Int64
+	restored  chan []byte
+	failed    bool
+	destroyed atomic.Bool
+}
+
+func (p *persistentProcessor) Destroy() {
+	p.destroyed.Store(true)
+}
+
+func (p *persistentProcessor) Snapshot() ([]byte, error) {
+	return []byte(strconv.FormatInt(p.counter.Load(), 10)), nil
+}
+
+func (p *persistentProcessor) Restore(data []byte) error {
+	if p.failed {
+		return errors.New("restore failed")
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	p.counter.Store(n)
+	p.restored <- data
+
+	return nil
+}
+
+func spawnPersistent(proxy *node.Proxy, processor *persistentProcessor, opts ...node.ActorOption) (*node.Actor, error) {
+	opts = append(opts, node.WithActorKind("persistent"), node.WithActorID("1"))
+
+	return proxy.Spawn(func(_ *node.Actor, _ ...any) node.Processor {
+		return processor
+	}, opts...)
+}
+
+func testSnapshotStore(t *testing.T, store node.SnapshotStore) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if data, err := store.Load(ctx, "kind/1"); err != nil || data != nil {
+		t.Fatalf("load missing snapshot, data: %v err: %v", data, err)
+	}
+
+	if err := store.Save(ctx, "kind/1", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Save(ctx, "kind/1", []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := store.Load(ctx, "kind/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "v2" {
+		t.Fatalf("snapshot mismatch, want: v2 got: %s", data)
+	}
+
+	// 修改加载结果不应影响已存储的快照
+	data[0] = 'x'
+
+	if data, _ = store.Load(ctx, "kind/1"); string(data) != "v2" {
+		t.Fatalf("snapshot is modified by caller: %s", data)
+	}
+
+	if err = store.Delete(ctx, "kind/1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = store.Delete(ctx, "kind/1"); err != nil {
+		t.Fatalf("delete missing snapshot: %v", err)
+	}
+
+	if data, err = store.Load(ctx, "kind/1"); err != nil || data != nil {
+		t.Fatalf("load deleted snapshot, data: %v err: %v", data, err)
+	}
+}
+
+func TestMemorySnapshotStore(t *testing.T) {
+	testSnapshotStore(t, node.NewMemorySnapshotStore())
+}
+
+func TestFileSnapshotStore(t *testing.T) {
+	testSnapshotStore(t, node.NewFileSnapshotStore(t.TempDir()))
+}
+
+func TestActorRestore(t *testing.T) {
+	store := node.NewMemorySnapshotStore()
+	proxy := node.NewNode(node.WithSnapshotStore(store)).Proxy()
+
+	if err := store.Save(context.Background(), "persistent/1", []byte("10")); err != nil {
+		t.Fatal(err)
+	}
+
+	processor := &persistentProcessor{restored: make(chan []byte, 1)}
+
+	actor, err := spawnPersistent(proxy, processor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case data := <-processor.restored:
+		if string(data) != "10" {
+			t.Fatalf("restored snapshot mismatch, want: 10 got: %s", data)
+		}
+	default:
+		t.Fatal("snapshot is not restored before spawn returns")
+	}
+
+	processor.counter.Add(5)
+
+	proxy.Kill(actor.Kind(), actor.ID())
+
+	data, err := store.Load(context.Background(), "persistent/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "15" {
+		t.Fatalf("snapshot on destroy mismatch, want: 15 got: %s", data)
+	}
+}
+
+func TestActorRestoreFailed(t *testing.T) {
+	store := node.NewMemorySnapshotStore()
+	proxy := node.NewNode(node.WithSnapshotStore(store)).Proxy()
+
+	if err := store.Save(context.Background(), "persistent/1", []byte("10")); err != nil {
+		t.Fatal(err)
+	}
+
+	processor := &persistentProcessor{restored: make(chan []byte, 1), failed: true}
+
+	if _, err := spawnPersistent(proxy, processor); err == nil {
+		t.Fatal("spawn should fail when restore failed")
+	}
+
+	if !processor.destroyed.Load() {
+		t.Fatal("processor is not destroyed after restore failed")
+	}
+
+	if _, ok := proxy.Actor("persistent", "1"); ok {
+		t.Fatal("actor should not be registered after restore failed")
+	}
+}
+
+func TestActorCheckpoint(t *testing.T) {
+	store := node.NewMemorySnapshotStore()
+	proxy := node.NewNode().Proxy()
+	processor := &persistentProcessor{restored: make(chan []byte, 1)}
+
+	actor, err := spawnPersistent(proxy, processor,
+		node.WithActorSnapshotStore(store),
+		node.WithActorCheckpoint(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Kill(actor.Kind(), actor.ID())
+
+	processor.counter.Store(7)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if data, _ := store.Load(context.Background(), "persistent/1"); string(data) == "7" {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("checkpoint snapshot is not saved")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
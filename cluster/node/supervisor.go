@@ -0,0 +1,214 @@
+package node
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/utils/xcall"
+)
+
+// Strategy 监督策略
+type Strategy int
+
+const (
+	OneForOne Strategy = iota // 一对一，仅对发生异常的Actor本身执行监督指令
+)
+
+// Directive 监督指令
+type Directive int
+
+const (
+	Restart  Directive = iota // 重启Actor，销毁当前处理器后重新创建
+	Resume                    // 忽略异常，继续处理后续消息
+	Stop                      // 停止并销毁Actor
+	Escalate                  // 停止Actor并将异常上报给最近的设置了监督者的祖先Actor处理，不存在时在根部记录错误日志
+)
+
+// LifecycleEvent Actor生命周期事件
+type LifecycleEvent int
+
+const (
+	ActorStarted   LifecycleEvent = iota + 1 // 已启动
+	ActorFailed                              // 发生异常
+	ActorRestarted                           // 已重启
+	ActorEscalated                           // 已上报异常
+	ActorStopped                             // 已停止
+)
+
+func (e LifecycleEvent) String() string {
+	switch e {
+	case ActorStarted:
+		return "started"
+	case ActorFailed:
+		return "failed"
+	case ActorRestarted:
+		return "restarted"
+	case ActorEscalated:
+		return "escalated"
+	case ActorStopped:
+		return "stopped"
+	}
+
+	return ""
+}
+
+// LifecycleHook Actor生命周期钩子
+type LifecycleHook func(actor *Actor, event LifecycleEvent, reason any)
+
+type Supervisor struct {
+	// 监督策略，默认为OneForOne
+	Strategy Strategy
+
+	// 时间窗口内允许的最大重启次数，超出后Actor将被停止，默认为0不限制
+	MaxRestarts int
+
+	// 重启次数统计的时间窗口，默认为0统计全部重启次数
+	Within time.Duration
+
+	// 监督决策器，根据异常原因返回监督指令，默认为Restart
+	Decider func(reason any) Directive
+
+	// 生命周期钩子，用于观测Actor的启动、异常、重启、上报与停止
+	Hook LifecycleHook
+}
+
+// 决策监督指令
+func (s *Supervisor) decide(reason any) Directive {
+	if s.Decider == nil {
+		return Restart
+	}
+
+	return s.Decider(reason)
+}
+
+// 上报生命周期事件
+func (a *Actor) report(event LifecycleEvent, reason any) {
+	if s := a.opts.supervisor; s != nil && s.Hook != nil {
+		xcall.Call(func() { s.Hook(a, event, reason) })
+	}
+}
+
+// 安全地调用函数，发生异常时交由监督者处理
+func (a *Actor) call(fn func()) {
+	defer func() {
+		if reason := recover(); reason != nil {
+			switch reason.(type) {
+			case runtime.Error:
+				log.Panic(reason)
+			default:
+				log.Panicf("panic error: %v", reason)
+			}
+
+			a.fail(reason)
+		}
+	}()
+
+	fn()
+}
+
+// 处理异常
+func (a *Actor) fail(reason any) {
+	s := a.opts.supervisor
+	if s == nil || a.state.Load() != started {
+		return
+	}
+
+	a.report(ActorFailed, reason)
+
+	directive := s.decide(reason)
+
+	if directive == Restart && !a.allowRestart() {
+		directive = Stop
+	}
+
+	switch directive {
+	case Restart:
+		a.restart()
+		a.report(ActorRestarted, reason)
+	case Stop:
+		a.stop()
+	case Escalate:
+		a.report(ActorEscalated, reason)
+		a.stop()
+		a.escalate(reason)
+	default:
+		// resume
+	}
+}
+
+// 上报异常
+// 未设置监督者的祖先Actor将被跳过，异常交由最近的设置了监督者的祖先Actor按其监督策略处理
+// 不存在可处理异常的祖先Actor时，异常在根部记录错误日志后丢弃，上报异常的Actor本身已被停止
+func (a *Actor) escalate(reason any) {
+	for parent := a.opts.parent; parent != nil; parent = parent.opts.parent {
+		if parent.opts.supervisor == nil || parent.state.Load() != started {
+			continue
+		}
+
+		ancestor := parent
+		ancestor.doInvoke(func() { ancestor.fail(reason) }, true)
+
+		return
+	}
+
+	log.Errorf("actor escalated failure is unhandled at root, pid = %v reason = %v", a.PID(), reason)
+}
+
+// 检测是否允许重启
+func (a *Actor) allowRestart() bool {
+	s := a.opts.supervisor
+
+	if s.MaxRestarts <= 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	if s.Within > 0 {
+		restarts := a.restarts[:0]
+		for _, t := range a.restarts {
+			if now.Sub(t) < s.Within {
+				restarts = append(restarts, t)
+			}
+		}
+		a.restarts = restarts
+	}
+
+	if len(a.restarts) >= s.MaxRestarts {
+		return false
+	}
+
+	a.restarts = append(a.restarts, now)
+
+	return true
+}
+
+// 重启Actor，仅在Actor的处理协程中调用
+func (a *Actor) restart() {
+	if a.processor != nil {
+		xcall.Call(a.processor.Destroy)
+	}
+
+	clear(a.routes)
+
+	clear(a.events)
+
+	a.defaultRouteHandler = nil
+
+	a.processor = a.creator(a, a.opts.args...)
+
+	xcall.Call(a.initProcessor)
+
+	if err := a.restore(); err != nil {
+		log.Errorf("actor restore failed, pid = %v err = %v", a.PID(), err)
+	}
+
+	xcall.Call(a.processor.Start)
+}
+
+// 停止Actor
+// 销毁Actor时需等待持有读锁的投递方写入完成，因此不能在Actor的处理协程中同步执行
+func (a *Actor) stop() {
+	xcall.Go(func() { a.scheduler.kill(a.Kind(), a.ID()) })
+}
@@ -0,0 +1,277 @@
+package node_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/cluster/node"
+)
+
+type supervisedProcessor struct {
+	node.BaseProcessor
+	actor   *node.Actor
+	inits   *atomic.Int32
+	handled chan int32
+}
+
+func (p *supervisedProcessor) Init() {
+	p.inits.Add(1)
+
+	for route := int32(1); route <= 3; route++ {
+		p.actor.AddRouteHandler(route, func(ctx node.Context) {
+			p.handled <- ctx.Route()
+		})
+	}
+
+	p.actor.AddEventHandler(cluster.Disconnect, func(ctx node.Context) {})
+}
+
+type lifecycle struct {
+	events chan node.LifecycleEvent
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{events: make(chan node.LifecycleEvent, 16)}
+}
+
+func (l *lifecycle) hook(_ *node.Actor, event node.LifecycleEvent, _ any) {
+	l.events <- event
+}
+
+func (l *lifecycle) expect(t *testing.T, events ...node.LifecycleEvent) {
+	t.Helper()
+
+	for _, want := range events {
+		select {
+		case got := <-l.events:
+			if got != want {
+				t.Fatalf("lifecycle event mismatch, want: %v got: %v", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("wait lifecycle event %v timeout", want)
+		}
+	}
+}
+
+func spawnSupervised(t *testing.T, proxy *node.Proxy, supervisor *node.Supervisor, opts ...node.ActorOption) (*node.Actor, *atomic.Int32, chan int32) {
+	t.Helper()
+
+	inits := &atomic.Int32{}
+	handled := make(chan int32, 1)
+
+	opts = append(opts,
+		node.WithActorKind("supervised"),
+		node.WithActorID("1"),
+		node.WithActorMailbox(1),
+		node.WithActorSupervisor(supervisor),
+	)
+
+	actor, err := proxy.Spawn(func(actor *node.Actor, _ ...any) node.Processor {
+		return &supervisedProcessor{actor: actor, inits: inits, handled: handled}
+	}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return actor, inits, handled
+}
+
+func expectHandled(t *testing.T, actor *node.Actor, handled chan int32, route int32) {
+	t.Helper()
+
+	if err := actor.Deliver(1, &cluster.Message{Route: route, Data: []byte("ping")}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-handled:
+		if got != route {
+			t.Fatalf("route mismatch, want: %d got: %d", route, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("route %d is not handled", route)
+	}
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	l := newLifecycle()
+	proxy := node.NewNode().Proxy()
+
+	actor, inits, handled := spawnSupervised(t, proxy, &node.Supervisor{Hook: l.hook})
+	l.expect(t, node.ActorStarted)
+
+	actor.Invoke(func() { panic("restart") })
+	l.expect(t, node.ActorFailed, node.ActorRestarted)
+
+	if n := inits.Load(); n != 2 {
+		t.Fatalf("processor init count mismatch, want: 2 got: %d", n)
+	}
+
+	// 邮箱容量为1时，重启期间重新注册多个处理器不应阻塞
+	for route := int32(1); route <= 3; route++ {
+		expectHandled(t, actor, handled, route)
+	}
+
+	proxy.Kill(actor.Kind(), actor.ID())
+	l.expect(t, node.ActorStopped)
+}
+
+func TestSupervisorResume(t *testing.T) {
+	l := newLifecycle()
+	proxy := node.NewNode().Proxy()
+
+	actor, inits, handled := spawnSupervised(t, proxy, &node.Supervisor{
+		Hook:    l.hook,
+		Decider: func(any) node.Directive { return node.Resume },
+	})
+	l.expect(t, node.ActorStarted)
+
+	actor.Invoke(func() { panic("resume") })
+	l.expect(t, node.ActorFailed)
+
+	expectHandled(t, actor, handled, 1)
+
+	if n := inits.Load(); n != 1 {
+		t.Fatalf("processor init count mismatch, want: 1 got: %d", n)
+	}
+
+	proxy.Kill(actor.Kind(), actor.ID())
+	l.expect(t, node.ActorStopped)
+}
+
+func TestSupervisorStop(t *testing.T) {
+	l := newLifecycle()
+	proxy := node.NewNode().Proxy()
+
+	actor, _, _ := spawnSupervised(t, proxy, &node.Supervisor{
+		Hook:    l.hook,
+		Decider: func(any) node.Directive { return node.Stop },
+	})
+	l.expect(t, node.ActorStarted)
+
+	actor.Invoke(func() { panic("stop") })
+	l.expect(t, node.ActorFailed, node.ActorStopped)
+
+	if _, ok := proxy.Actor(actor.Kind(), actor.ID()); ok {
+		t.Fatal("stopped actor is still registered")
+	}
+}
+
+func TestSupervisorMaxRestarts(t *testing.T) {
+	l := newLifecycle()
+	proxy := node.NewNode().Proxy()
+
+	actor, inits, _ := spawnSupervised(t, proxy, &node.Supervisor{
+		Hook:        l.hook,
+		MaxRestarts: 1,
+		Within:      time.Minute,
+	})
+	l.expect(t, node.ActorStarted)
+
+	actor.Invoke(func() { panic("first") })
+	l.expect(t, node.ActorFailed, node.ActorRestarted)
+
+	actor.Invoke(func() { panic("second") })
+	l.expect(t, node.ActorFailed, node.ActorStopped)
+
+	if n := inits.Load(); n != 2 {
+		t.Fatalf("processor init count mismatch, want: 2 got: %d", n)
+	}
+}
+
+func TestSupervisorEscalate(t *testing.T) {
+	parentEvents := newLifecycle()
+	childEvents := newLifecycle()
+	proxy := node.NewNode().Proxy()
+
+	parent, parentInits, _ := spawnSupervised(t, proxy, &node.Supervisor{Hook: parentEvents.hook})
+	parentEvents.expect(t, node.ActorStarted)
+
+	child, err := parent.Spawn(func(actor *node.Actor, _ ...any) node.Processor {
+		return &node.BaseProcessor{}
+	}, node.WithActorKind("child"), node.WithActorID("1"), node.WithActorSupervisor(&node.Supervisor{
+		Hook:    childEvents.hook,
+		Decider: func(any) node.Directive { return node.Escalate },
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	childEvents.expect(t, node.ActorStarted)
+
+	if child.Parent() != parent {
+		t.Fatal("parent mismatch")
+	}
+
+	child.Invoke(func() { panic("escalate") })
+	childEvents.expect(t, node.ActorFailed, node.ActorEscalated, node.ActorStopped)
+	parentEvents.expect(t, node.ActorFailed, node.ActorRestarted)
+
+	if n := parentInits.Load(); n != 2 {
+		t.Fatalf("parent init count mismatch, want: 2 got: %d", n)
+	}
+
+	if _, ok := proxy.Actor(child.Kind(), child.ID()); ok {
+		t.Fatal("escalated actor is still registered")
+	}
+}
+
+func TestSupervisorEscalate_SkipUnsupervised(t *testing.T) {
+	rootEvents := newLifecycle()
+	childEvents := newLifecycle()
+	proxy := node.NewNode().Proxy()
+
+	root, rootInits, _ := spawnSupervised(t, proxy, &node.Supervisor{Hook: rootEvents.hook})
+	rootEvents.expect(t, node.ActorStarted)
+
+	// 未设置监督者的父级Actor将被跳过，异常交由祖先Actor处理
+	parent, err := root.Spawn(func(actor *node.Actor, _ ...any) node.Processor {
+		return &node.BaseProcessor{}
+	}, node.WithActorKind("parent"), node.WithActorID("1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	child, err := parent.Spawn(func(actor *node.Actor, _ ...any) node.Processor {
+		return &node.BaseProcessor{}
+	}, node.WithActorKind("child"), node.WithActorID("1"), node.WithActorSupervisor(&node.Supervisor{
+		Hook:    childEvents.hook,
+		Decider: func(any) node.Directive { return node.Escalate },
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	childEvents.expect(t, node.ActorStarted)
+
+	child.Invoke(func() { panic("escalate") })
+	childEvents.expect(t, node.ActorFailed, node.ActorEscalated, node.ActorStopped)
+	rootEvents.expect(t, node.ActorFailed, node.ActorRestarted)
+
+	if n := rootInits.Load(); n != 2 {
+		t.Fatalf("root init count mismatch, want: 2 got: %d", n)
+	}
+
+	if _, ok := proxy.Actor(parent.Kind(), parent.ID()); !ok {
+		t.Fatal("unsupervised parent should not be stopped")
+	}
+}
+
+func TestSupervisorEscalate_Root(t *testing.T) {
+	l := newLifecycle()
+	proxy := node.NewNode().Proxy()
+
+	// 不存在父级Actor时，异常在根部记录错误日志，上报异常的Actor被停止
+	actor, _, _ := spawnSupervised(t, proxy, &node.Supervisor{
+		Hook:    l.hook,
+		Decider: func(any) node.Directive { return node.Escalate },
+	})
+	l.expect(t, node.ActorStarted)
+
+	actor.Invoke(func() { panic("escalate") })
+	l.expect(t, node.ActorFailed, node.ActorEscalated, node.ActorStopped)
+
+	if _, ok := proxy.Actor(actor.Kind(), actor.ID()); ok {
+		t.Fatal("escalated actor is still registered")
+	}
+}
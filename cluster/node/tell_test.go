@@ -0,0 +1,140 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/cluster/node"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/locate"
+)
+
+const (
+	tellRoute  = 1
+	replyRoute = 2
+)
+
+type tellProcessor struct {
+	node.BaseProcessor
+	actor    *node.Actor
+	received chan string
+}
+
+func (p *tellProcessor) Init() {
+	p.actor.AddRouteHandler(tellRoute, func(ctx node.Context) {
+		if err := ctx.Reply(&cluster.Message{Route: replyRoute, Data: map[string]string{"message": "pong"}}); err != nil {
+			p.received <- err.Error()
+		} else {
+			p.parse(ctx)
+		}
+	})
+
+	p.actor.AddRouteHandler(replyRoute, p.parse)
+}
+
+// 记录收到的消息内容
+func (p *tellProcessor) parse(ctx node.Context) {
+	message := make(map[string]string)
+
+	if err := ctx.Parse(&message); err != nil {
+		p.received <- err.Error()
+	} else {
+		p.received <- message["message"]
+	}
+}
+
+func spawnTeller(t *testing.T, n *node.Node, id string) (*node.Actor, chan string) {
+	t.Helper()
+
+	received := make(chan string, 2)
+
+	actor, err := n.Proxy().Spawn(func(actor *node.Actor, _ ...any) node.Processor {
+		return &tellProcessor{actor: actor, received: received}
+	}, node.WithActorKind("tell"), node.WithActorID(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return actor, received
+}
+
+func TestActor_TellReply(t *testing.T) {
+	n := node.NewNode(node.WithCodec(json.DefaultCodec))
+
+	sender, replied := spawnTeller(t, n, "1")
+	defer n.Proxy().Kill(sender.Kind(), sender.ID())
+
+	receiver, received := spawnTeller(t, n, "2")
+	defer n.Proxy().Kill(receiver.Kind(), receiver.ID())
+
+	if err := sender.Tell(context.Background(), &cluster.TellArgs{
+		PID:     receiver.PID(),
+		UID:     1,
+		Message: &cluster.Message{Route: tellRoute, Data: map[string]string{"message": "ping"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expectReceived(t, received, "ping")
+	expectReceived(t, replied, "pong")
+
+	// 直接投递的消息没有来源Actor，即便复用了此前Tell的请求对象，回复也不应投递给发送方
+	if err := receiver.Deliver(1, &cluster.Message{Route: tellRoute, Data: map[string]string{"message": "ping"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	expectReceived(t, received, "ping")
+
+	select {
+	case message := <-replied:
+		t.Fatalf("reply is delivered to unrelated actor: %s", message)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+type actorLocator struct {
+	locate.Locator
+	nodes map[string]string
+}
+
+func (l *actorLocator) BindActor(_ context.Context, _, _, _ string) error { return nil }
+
+func (l *actorLocator) UnbindActor(_ context.Context, _, _, _ string) error { return nil }
+
+func (l *actorLocator) LocateActor(_ context.Context, kind, id string) (string, error) {
+	return l.nodes[kind+"/"+id], nil
+}
+
+func TestProxy_LocateActor(t *testing.T) {
+	n := node.NewNode(node.WithID("node-1"))
+
+	actor, _ := spawnTeller(t, n, "1")
+	defer n.Proxy().Kill(actor.Kind(), actor.ID())
+
+	if _, err := n.Proxy().LocateActor(context.Background(), "tell", "2"); !errors.Is(err, errors.ErrNotFoundLocator) {
+		t.Fatalf("locate error mismatch, want: %v got: %v", errors.ErrNotFoundLocator, err)
+	}
+
+	n = node.NewNode(node.WithID("node-1"), node.WithLocator(&actorLocator{nodes: map[string]string{"tell/2": "node-2"}}))
+
+	actor, _ = spawnTeller(t, n, "1")
+	defer n.Proxy().Kill(actor.Kind(), actor.ID())
+
+	for _, c := range []struct {
+		id  string
+		nid string
+		err error
+	}{
+		{id: "1", nid: "node-1"},
+		{id: "2", nid: "node-2"},
+		{id: "3", err: errors.ErrNotFoundActor},
+	} {
+		nid, err := n.Proxy().LocateActor(context.Background(), "tell", c.id)
+		if !errors.Is(err, c.err) || nid != c.nid {
+			t.Fatalf("locate tell/%s mismatch, want: %q %v got: %q %v", c.id, c.nid, c.err, nid, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/trace"
 	"github.com/dobyte/due/v2/utils/xcall"
 )
 
@@ -23,9 +24,9 @@ func newTrigger(node *Node) *Trigger {
 	}
 }
 
-func (e *Trigger) trigger(kind cluster.Event, gid string, cid, uid int64) {
+func (e *Trigger) trigger(ctx context.Context, kind cluster.Event, gid string, cid, uid int64) {
 	evt := e.node.evtPool.Get().(*event)
-	evt.ctx = context.Background()
+	evt.ctx = ctx
 	evt.event = kind
 	evt.gid = gid
 	evt.cid = cid
@@ -46,6 +47,13 @@ func (e *Trigger) handle(evt *event) {
 	version := evt.incrVersion()
 
 	if handler, ok := e.events[evt.event]; ok {
+		var span *trace.Span
+		evt.ctx, span = trace.Start(evt.ctx, "node.event")
+		span.SetAttribute("event", evt.event.String())
+		span.SetAttribute("cid", evt.cid)
+		span.SetAttribute("uid", evt.uid)
+		defer span.Finish()
+
 		xcall.Call(func() { handler(evt) })
 
 		evt.compareVersionExecDefer(version)
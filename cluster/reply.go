@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/encoding"
+	"github.com/dobyte/due/v2/encoding/proto"
+	"github.com/dobyte/due/v2/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	codeReplyCodeField    protowire.Number = 1 // 错误码字段
+	codeReplyMessageField protowire.Number = 2 // 错误信息字段
+)
+
+// CodeReply 错误码回复消息
+// 网关与节点在请求被拒绝（鉴权失败、限流、邮箱溢出、超时等）时默认回复此消息
+// 使用proto编解码器时按 message CodeReply { int32 code = 1; string message = 2; } 进行编码，其余编解码器按结构体标签进行编码
+type CodeReply struct {
+	Code    int    `json:"code" xml:"code" yaml:"code" toml:"code" msgpack:"code"`
+	Message string `json:"message" xml:"message" yaml:"message" toml:"message" msgpack:"message"`
+}
+
+// MarshalCodeReply 使用编解码器编码错误码回复消息
+func MarshalCodeReply(codec encoding.Codec, code *codes.Code) ([]byte, error) {
+	if codec == nil {
+		return nil, errors.ErrInvalidArgument
+	}
+
+	if codec.Name() != proto.Name {
+		return codec.Marshal(&CodeReply{Code: code.Code(), Message: code.Message()})
+	}
+
+	var buf []byte
+
+	if code.Code() != 0 {
+		buf = protowire.AppendTag(buf, codeReplyCodeField, protowire.VarintType)
+		buf = protowire.AppendVarint(buf, uint64(int32(code.Code())))
+	}
+
+	if code.Message() != "" {
+		buf = protowire.AppendTag(buf, codeReplyMessageField, protowire.BytesType)
+		buf = protowire.AppendString(buf, code.Message())
+	}
+
+	return buf, nil
+}
+
+// UnmarshalCodeReply 使用编解码器解码错误码回复消息
+func UnmarshalCodeReply(codec encoding.Codec, data []byte) (*CodeReply, error) {
+	if codec == nil {
+		return nil, errors.ErrInvalidArgument
+	}
+
+	reply := &CodeReply{}
+
+	if codec.Name() != proto.Name {
+		if err := codec.Unmarshal(data, reply); err != nil {
+			return nil, err
+		}
+
+		return reply, nil
+	}
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		switch {
+		case num == codeReplyCodeField && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(data)
+			if m < 0 {
+				return nil, protowire.ParseError(m)
+			}
+			reply.Code, n = int(int32(v)), m
+		case num == codeReplyMessageField && typ == protowire.BytesType:
+			v, m := protowire.ConsumeString(data)
+			if m < 0 {
+				return nil, protowire.ParseError(m)
+			}
+			reply.Message, n = v, m
+		default:
+			if n = protowire.ConsumeFieldValue(num, typ, data); n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+		}
+
+		data = data[n:]
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,31 @@
+package cluster_test
+
+import (
+	"testing"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/codes"
+	"github.com/dobyte/due/v2/encoding"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/encoding/proto"
+)
+
+func TestCodeReply(t *testing.T) {
+	for _, codec := range []encoding.Codec{proto.DefaultCodec, json.DefaultCodec} {
+		for _, code := range []*codes.Code{codes.TooManyRequests, codes.NewCode(-1, "negative"), codes.NewCode(0, "")} {
+			data, err := cluster.MarshalCodeReply(codec, code)
+			if err != nil {
+				t.Fatalf("%s marshal failed: %v", codec.Name(), err)
+			}
+
+			reply, err := cluster.UnmarshalCodeReply(codec, data)
+			if err != nil {
+				t.Fatalf("%s unmarshal failed: %v", codec.Name(), err)
+			}
+
+			if reply.Code != code.Code() || reply.Message != code.Message() {
+				t.Fatalf("%s code reply mismatch, want: %d %s got: %+v", codec.Name(), code.Code(), code.Message(), reply)
+			}
+		}
+	}
+}
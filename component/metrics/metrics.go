@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/dobyte/due/v2/component"
+	"github.com/dobyte/due/v2/core/info"
+	xnet "github.com/dobyte/due/v2/core/net"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	xmetrics "github.com/dobyte/due/v2/metrics"
+)
+
+var _ component.Component = &Metrics{}
+
+type Metrics struct {
+	component.Base
+	opts   *options
+	server *http.Server
+}
+
+func NewMetrics(opts ...Option) *Metrics {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Metrics{opts: o}
+}
+
+func (*Metrics) Name() string {
+	return "metrics"
+}
+
+func (m *Metrics) Init() {
+	if m.opts.registry != nil {
+		xmetrics.SetRegistry(m.opts.registry)
+	}
+}
+
+func (m *Metrics) Start() {
+	listenAddr, exposeAddr, err := xnet.ParseAddr(m.opts.addr)
+	if err != nil {
+		log.Fatalf("metrics addr parse failed: %v", err)
+	}
+
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		log.Fatalf("metrics server listen failed: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(m.opts.path, xmetrics.Handler())
+
+	m.server = &http.Server{Handler: mux}
+
+	go func() {
+		if err := m.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("metrics server start failed: %v", err)
+		}
+	}()
+
+	info.PrintBoxInfo("Metrics",
+		fmt.Sprintf("Url: http://%s%s", exposeAddr, m.opts.path),
+	)
+}
+
+func (m *Metrics) Destroy() {
+	if m.server == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := m.server.Shutdown(ctx); err != nil {
+		log.Warnf("metrics server shutdown failed: %v", err)
+	}
+}
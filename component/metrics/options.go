@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"github.com/dobyte/due/v2/etc"
+	xmetrics "github.com/dobyte/due/v2/metrics"
+)
+
+const (
+	defaultAddr = ":0"       // 监听地址
+	defaultPath = "/metrics" // 指标导出路径
+)
+
+const (
+	defaultAddrKey = "etc.metrics.addr"
+	defaultPathKey = "etc.metrics.path"
+)
+
+type Option func(o *options)
+
+type options struct {
+	addr     string            // 监听地址
+	path     string            // 指标导出路径，默认为/metrics
+	registry xmetrics.Registry // 指标注册器，默认为全局指标注册器
+}
+
+func defaultOptions() *options {
+	opts := &options{
+		addr: defaultAddr,
+		path: defaultPath,
+	}
+
+	if addr := etc.Get(defaultAddrKey).String(); addr != "" {
+		opts.addr = addr
+	}
+
+	if path := etc.Get(defaultPathKey).String(); path != "" {
+		opts.path = path
+	}
+
+	return opts
+}
+
+// WithAddr 设置监听地址
+func WithAddr(addr string) Option {
+	return func(o *options) { o.addr = addr }
+}
+
+// WithPath 设置指标导出路径
+func WithPath(path string) Option {
+	return func(o *options) { o.path = path }
+}
+
+// WithRegistry 设置指标注册器
+func WithRegistry(registry xmetrics.Registry) Option {
+	return func(o *options) { o.registry = registry }
+}
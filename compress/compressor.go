@@ -0,0 +1,59 @@
+package compress
+
+import (
+	"github.com/dobyte/due/v2/compress/flate"
+	"github.com/dobyte/due/v2/compress/gzip"
+	"github.com/dobyte/due/v2/log"
+)
+
+var compressors = make(map[string]Compressor)
+
+func init() {
+	Register(gzip.DefaultCompressor)
+	Register(flate.DefaultCompressor)
+}
+
+type Compressor interface {
+	// Name 压缩器名称
+	Name() string
+	// Compress 压缩
+	Compress(data []byte) ([]byte, error)
+	// Decompress 解压缩
+	Decompress(data []byte) ([]byte, error)
+}
+
+// LimitedDecompressor 可限制解压缩数据长度的压缩器
+// 解压缩来自网络的数据时应优先使用此接口，避免恶意构造的压缩数据在解压缩时耗尽内存
+type LimitedDecompressor interface {
+	// DecompressLimit 解压缩，解压缩后的数据超过limit字节时返回errors.ErrMessageTooLarge
+	DecompressLimit(data []byte, limit int) ([]byte, error)
+}
+
+// Register 注册压缩器
+func Register(compressor Compressor) {
+	if compressor == nil {
+		log.Fatal("can't register a invalid compressor")
+	}
+
+	name := compressor.Name()
+
+	if name == "" {
+		log.Fatal("can't register a compressor without name")
+	}
+
+	if _, ok := compressors[name]; ok {
+		log.Warnf("the old %s compressor will be overwritten", name)
+	}
+
+	compressors[name] = compressor
+}
+
+// Invoke 调用压缩器
+func Invoke(name string) Compressor {
+	compressor, ok := compressors[name]
+	if !ok {
+		log.Fatalf("%s compressor is not registered", name)
+	}
+
+	return compressor
+}
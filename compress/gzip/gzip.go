@@ -0,0 +1,87 @@
+package gzip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+
+	"github.com/dobyte/due/v2/compress/internal"
+)
+
+const Name = "gzip"
+
+var DefaultCompressor = NewCompressor(gzip.DefaultCompression)
+
+type compressor struct {
+	level   int
+	writers sync.Pool
+}
+
+// NewCompressor 新建gzip压缩器
+func NewCompressor(level int) *compressor {
+	return &compressor{level: level}
+}
+
+// Name 压缩器名称
+func (c *compressor) Name() string {
+	return Name
+}
+
+// Compress 压缩
+func (c *compressor) Compress(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	w, ok := c.writers.Get().(*gzip.Writer)
+	if ok {
+		w.Reset(buf)
+	} else {
+		var err error
+		if w, err = gzip.NewWriterLevel(buf, c.level); err != nil {
+			return nil, err
+		}
+	}
+	defer c.writers.Put(w)
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decompress 解压缩
+func (c *compressor) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// DecompressLimit 解压缩，解压缩后的数据超过limit字节时返回errors.ErrMessageTooLarge
+func (c *compressor) DecompressLimit(data []byte, limit int) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return internal.ReadAll(r, limit)
+}
+
+// Compress 压缩
+func Compress(data []byte) ([]byte, error) {
+	return DefaultCompressor.Compress(data)
+}
+
+// Decompress 解压缩
+func Decompress(data []byte) ([]byte, error) {
+	return DefaultCompressor.Decompress(data)
+}
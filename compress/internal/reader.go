@@ -0,0 +1,21 @@
+package internal
+
+import (
+	"io"
+
+	"github.com/dobyte/due/v2/errors"
+)
+
+// ReadAll 读取全部数据，数据长度超过limit字节时返回errors.ErrMessageTooLarge
+func ReadAll(r io.Reader, limit int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > limit {
+		return nil, errors.ErrMessageTooLarge
+	}
+
+	return data, nil
+}
@@ -0,0 +1,253 @@
+package aoi
+
+import "sort"
+
+const defaultRadius = 10 // 默认视野半径
+
+const (
+	Enter Event = iota + 1 // 进入视野
+	Leave                  // 离开视野
+	Move                   // 在视野内移动
+)
+
+type Event int
+
+func (e Event) String() string {
+	switch e {
+	case Enter:
+		return "enter"
+	case Leave:
+		return "leave"
+	case Move:
+		return "move"
+	}
+
+	return ""
+}
+
+// Handler AOI事件处理器，watcher为观察者，target为进入、离开观察者视野或在观察者视野内移动的实体
+type Handler func(event Event, watcher, target int64)
+
+// AOI 兴趣区域管理器
+// 实体间的视野是对称的，两个实体距离不超过视野半径时互相可见
+// AOI非线程安全，推荐由一个Actor（如场景Actor）独占，所有操作与事件回调均在Actor的处理协程中执行
+type AOI interface {
+	// Enter 实体进入，实体已存在时等同于Move
+	Enter(id int64, x, y float64)
+	// Leave 实体离开
+	Leave(id int64)
+	// Move 实体移动，实体不存在时等同于Enter
+	Move(id int64, x, y float64)
+	// Position 获取实体位置
+	Position(id int64) (x, y float64, ok bool)
+	// Neighbors 获取实体视野内的其他实体
+	Neighbors(id int64) []int64
+	// Range 获取以(x, y)为圆心、radius为半径的范围内的所有实体
+	Range(x, y, radius float64) []int64
+	// Count 获取实体总数
+	Count() int
+}
+
+type Option func(o *options)
+
+type options struct {
+	radius  float64 // 视野半径，默认为10
+	handler Handler // 事件处理器
+}
+
+func defaultOptions() *options {
+	return &options{radius: defaultRadius}
+}
+
+// WithRadius 设置视野半径
+func WithRadius(radius float64) Option {
+	return func(o *options) { o.radius = radius }
+}
+
+// WithHandler 设置事件处理器
+func WithHandler(handler Handler) Option {
+	return func(o *options) { o.handler = handler }
+}
+
+type entity struct {
+	id    int64
+	x     float64
+	y     float64
+	cell  cell    // 所在网格，仅网格实现使用
+	xPrev *entity // X轴上的前一个实体，仅十字链表实现使用
+	xNext *entity // X轴上的后一个实体，仅十字链表实现使用
+	yPrev *entity // Y轴上的前一个实体，仅十字链表实现使用
+	yNext *entity // Y轴上的后一个实体，仅十字链表实现使用
+}
+
+// 空间索引
+type index interface {
+	// 插入实体
+	insert(e *entity)
+	// 移除实体
+	remove(e *entity)
+	// 更新实体位置
+	update(e *entity, x, y float64)
+	// 查找范围内的实体，anchor为范围中心附近的实体，可为nil
+	search(x, y, radius float64, anchor *entity, fn func(e *entity))
+}
+
+type area struct {
+	opts     *options
+	index    index
+	entities map[int64]*entity
+}
+
+func newArea(index index, opts ...Option) *area {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &area{opts: o, index: index, entities: make(map[int64]*entity)}
+}
+
+// Enter 实体进入，实体已存在时等同于Move
+func (a *area) Enter(id int64, x, y float64) {
+	if _, ok := a.entities[id]; ok {
+		a.Move(id, x, y)
+		return
+	}
+
+	e := &entity{id: id, x: x, y: y}
+
+	a.entities[id] = e
+	a.index.insert(e)
+
+	for _, neighbor := range a.neighbors(e) {
+		a.emit(Enter, neighbor, id)
+		a.emit(Enter, id, neighbor)
+	}
+}
+
+// Leave 实体离开
+func (a *area) Leave(id int64) {
+	e, ok := a.entities[id]
+	if !ok {
+		return
+	}
+
+	neighbors := a.neighbors(e)
+
+	a.index.remove(e)
+	delete(a.entities, id)
+
+	for _, neighbor := range neighbors {
+		a.emit(Leave, neighbor, id)
+	}
+}
+
+// Move 实体移动，实体不存在时等同于Enter
+func (a *area) Move(id int64, x, y float64) {
+	e, ok := a.entities[id]
+	if !ok {
+		a.Enter(id, x, y)
+		return
+	}
+
+	olds := make(map[int64]struct{})
+	for _, neighbor := range a.neighbors(e) {
+		olds[neighbor] = struct{}{}
+	}
+
+	a.index.update(e, x, y)
+
+	for _, neighbor := range a.neighbors(e) {
+		if _, ok = olds[neighbor]; ok {
+			delete(olds, neighbor)
+			a.emit(Move, neighbor, id)
+		} else {
+			a.emit(Enter, neighbor, id)
+			a.emit(Enter, id, neighbor)
+		}
+	}
+
+	leaves := make([]int64, 0, len(olds))
+	for neighbor := range olds {
+		leaves = append(leaves, neighbor)
+	}
+
+	sortIDs(leaves)
+
+	for _, neighbor := range leaves {
+		a.emit(Leave, neighbor, id)
+		a.emit(Leave, id, neighbor)
+	}
+}
+
+// Position 获取实体位置
+func (a *area) Position(id int64) (float64, float64, bool) {
+	e, ok := a.entities[id]
+	if !ok {
+		return 0, 0, false
+	}
+
+	return e.x, e.y, true
+}
+
+// Neighbors 获取实体视野内的其他实体
+func (a *area) Neighbors(id int64) []int64 {
+	e, ok := a.entities[id]
+	if !ok {
+		return nil
+	}
+
+	return a.neighbors(e)
+}
+
+// Range 获取以(x, y)为圆心、radius为半径的范围内的所有实体
+func (a *area) Range(x, y, radius float64) []int64 {
+	ids := make([]int64, 0)
+
+	a.index.search(x, y, radius, nil, func(e *entity) {
+		ids = append(ids, e.id)
+	})
+
+	sortIDs(ids)
+
+	return ids
+}
+
+// Count 获取实体总数
+func (a *area) Count() int {
+	return len(a.entities)
+}
+
+// 获取实体视野内的其他实体
+func (a *area) neighbors(e *entity) []int64 {
+	ids := make([]int64, 0)
+
+	a.index.search(e.x, e.y, a.opts.radius, e, func(n *entity) {
+		if n != e {
+			ids = append(ids, n.id)
+		}
+	})
+
+	sortIDs(ids)
+
+	return ids
+}
+
+// 触发事件
+func (a *area) emit(event Event, watcher, target int64) {
+	if a.opts.handler != nil {
+		a.opts.handler(event, watcher, target)
+	}
+}
+
+// 检测两点间距离是否在半径范围内
+func within(x1, y1, x2, y2, radius float64) bool {
+	dx, dy := x1-x2, y1-y2
+
+	return dx*dx+dy*dy <= radius*radius
+}
+
+// 对实体ID排序，保证事件触发顺序稳定
+func sortIDs(ids []int64) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
@@ -0,0 +1,137 @@
+package aoi_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/dobyte/due/v2/core/aoi"
+)
+
+var creators = map[string]func(opts ...aoi.Option) aoi.AOI{
+	"grid":      aoi.NewGrid,
+	"crosslist": aoi.NewCrossList,
+}
+
+func TestAOI_Events(t *testing.T) {
+	for name, creator := range creators {
+		t.Run(name, func(t *testing.T) {
+			events := make([]string, 0)
+
+			area := creator(aoi.WithRadius(10), aoi.WithHandler(func(event aoi.Event, watcher, target int64) {
+				events = append(events, fmt.Sprintf("%s:%d:%d", event, watcher, target))
+			}))
+
+			area.Enter(1, 0, 0)
+			area.Enter(2, 5, 0)
+			area.Enter(3, 50, 50)
+
+			expect(t, events, "enter:1:2", "enter:2:1")
+			events = events[:0]
+
+			area.Move(2, 8, 0)
+			expect(t, events, "move:1:2")
+			events = events[:0]
+
+			area.Move(2, 45, 50)
+			expect(t, events, "enter:3:2", "enter:2:3", "leave:1:2", "leave:2:1")
+			events = events[:0]
+
+			area.Leave(3)
+			expect(t, events, "leave:2:3")
+
+			if n := area.Count(); n != 2 {
+				t.Fatalf("count mismatch, want: 2 got: %d", n)
+			}
+		})
+	}
+}
+
+func TestAOI_Neighbors(t *testing.T) {
+	const (
+		radius = 10
+		size   = 100
+	)
+
+	for name, creator := range creators {
+		t.Run(name, func(t *testing.T) {
+			var (
+				r         = rand.New(rand.NewSource(1))
+				area      = creator(aoi.WithRadius(radius))
+				positions = make(map[int64][2]float64)
+			)
+
+			for i := 0; i < 2000; i++ {
+				id := int64(r.Intn(100) + 1)
+
+				switch r.Intn(5) {
+				case 0:
+					area.Leave(id)
+					delete(positions, id)
+				default:
+					x, y := r.Float64()*size-size/2, r.Float64()*size-size/2
+					area.Move(id, x, y)
+					positions[id] = [2]float64{x, y}
+				}
+
+				for id, pos := range positions {
+					if neighbors, want := area.Neighbors(id), brute(positions, id, pos[0], pos[1], radius); !slices.Equal(neighbors, want) {
+						t.Fatalf("neighbors of %d mismatch, want: %v got: %v", id, want, neighbors)
+					}
+				}
+			}
+
+			if ids, want := area.Range(0, 0, 30), brute(positions, 0, 0, 0, 30); !slices.Equal(ids, want) {
+				t.Fatalf("range mismatch, want: %v got: %v", want, ids)
+			}
+		})
+	}
+}
+
+func BenchmarkAOI_Move(b *testing.B) {
+	for name, creator := range creators {
+		b.Run(name, func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			area := creator(aoi.WithRadius(10))
+
+			for i := int64(1); i <= 1000; i++ {
+				area.Enter(i, r.Float64()*500, r.Float64()*500)
+			}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				id := int64(i%1000 + 1)
+				x, y, _ := area.Position(id)
+				area.Move(id, x+r.Float64()*2-1, y+r.Float64()*2-1)
+			}
+		})
+	}
+}
+
+func expect(t *testing.T, events []string, want ...string) {
+	t.Helper()
+
+	if !slices.Equal(events, want) {
+		t.Fatalf("events mismatch, want: %v got: %v", want, events)
+	}
+}
+
+func brute(positions map[int64][2]float64, self int64, x, y, radius float64) []int64 {
+	ids := make([]int64, 0)
+
+	for id, pos := range positions {
+		if id == self {
+			continue
+		}
+
+		if dx, dy := pos[0]-x, pos[1]-y; dx*dx+dy*dy <= radius*radius {
+			ids = append(ids, id)
+		}
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
@@ -0,0 +1,186 @@
+package aoi
+
+type crossList struct {
+	xHead *entity // X轴链表头，按X坐标升序
+	yHead *entity // Y轴链表头，按Y坐标升序
+}
+
+// NewCrossList 创建十字链表实现的AOI
+// 实体分别按X、Y坐标有序链接，移动时仅需在链表中局部调整位置，适用于实体数量适中且移动频繁的场景
+func NewCrossList(opts ...Option) AOI {
+	return newArea(&crossList{}, opts...)
+}
+
+// 插入实体
+func (l *crossList) insert(e *entity) {
+	l.insertX(e)
+	l.insertY(e)
+}
+
+// 移除实体
+func (l *crossList) remove(e *entity) {
+	l.unlinkX(e)
+	l.unlinkY(e)
+}
+
+// 更新实体位置
+func (l *crossList) update(e *entity, x, y float64) {
+	e.x, e.y = x, y
+
+	if (e.xPrev != nil && e.xPrev.x > x) || (e.xNext != nil && e.xNext.x < x) {
+		prev, next := e.xPrev, e.xNext
+		l.unlinkX(e)
+		l.relinkX(e, prev, next)
+	}
+
+	if (e.yPrev != nil && e.yPrev.y > y) || (e.yNext != nil && e.yNext.y < y) {
+		prev, next := e.yPrev, e.yNext
+		l.unlinkY(e)
+		l.relinkY(e, prev, next)
+	}
+}
+
+// 查找范围内的实体
+func (l *crossList) search(x, y, radius float64, anchor *entity, fn func(e *entity)) {
+	if anchor == nil {
+		for e := l.xHead; e != nil && e.x <= x+radius; e = e.xNext {
+			if e.x >= x-radius && within(x, y, e.x, e.y, radius) {
+				fn(e)
+			}
+		}
+
+		return
+	}
+
+	if within(x, y, anchor.x, anchor.y, radius) {
+		fn(anchor)
+	}
+
+	for e := anchor.xPrev; e != nil && e.x >= x-radius; e = e.xPrev {
+		if within(x, y, e.x, e.y, radius) {
+			fn(e)
+		}
+	}
+
+	for e := anchor.xNext; e != nil && e.x <= x+radius; e = e.xNext {
+		if within(x, y, e.x, e.y, radius) {
+			fn(e)
+		}
+	}
+}
+
+// 从链表头开始查找位置并插入X轴链表
+func (l *crossList) insertX(e *entity) {
+	var prev *entity
+
+	for next := l.xHead; next != nil && next.x < e.x; next = next.xNext {
+		prev = next
+	}
+
+	l.linkX(e, prev)
+}
+
+// 从链表头开始查找位置并插入Y轴链表
+func (l *crossList) insertY(e *entity) {
+	var prev *entity
+
+	for next := l.yHead; next != nil && next.y < e.y; next = next.yNext {
+		prev = next
+	}
+
+	l.linkY(e, prev)
+}
+
+// 从原位置附近查找位置并重新插入X轴链表
+func (l *crossList) relinkX(e, prev, next *entity) {
+	if prev != nil && prev.x > e.x {
+		for prev != nil && prev.x > e.x {
+			prev = prev.xPrev
+		}
+	} else {
+		for next != nil && next.x < e.x {
+			prev, next = next, next.xNext
+		}
+	}
+
+	l.linkX(e, prev)
+}
+
+// 从原位置附近查找位置并重新插入Y轴链表
+func (l *crossList) relinkY(e, prev, next *entity) {
+	if prev != nil && prev.y > e.y {
+		for prev != nil && prev.y > e.y {
+			prev = prev.yPrev
+		}
+	} else {
+		for next != nil && next.y < e.y {
+			prev, next = next, next.yNext
+		}
+	}
+
+	l.linkY(e, prev)
+}
+
+// 将实体链接到X轴链表中prev之后，prev为nil时链接到链表头
+func (l *crossList) linkX(e, prev *entity) {
+	e.xPrev = prev
+
+	if prev == nil {
+		e.xNext = l.xHead
+		l.xHead = e
+	} else {
+		e.xNext = prev.xNext
+		prev.xNext = e
+	}
+
+	if e.xNext != nil {
+		e.xNext.xPrev = e
+	}
+}
+
+// 将实体链接到Y轴链表中prev之后，prev为nil时链接到链表头
+func (l *crossList) linkY(e, prev *entity) {
+	e.yPrev = prev
+
+	if prev == nil {
+		e.yNext = l.yHead
+		l.yHead = e
+	} else {
+		e.yNext = prev.yNext
+		prev.yNext = e
+	}
+
+	if e.yNext != nil {
+		e.yNext.yPrev = e
+	}
+}
+
+// 从X轴链表中移除实体
+func (l *crossList) unlinkX(e *entity) {
+	if e.xPrev != nil {
+		e.xPrev.xNext = e.xNext
+	} else {
+		l.xHead = e.xNext
+	}
+
+	if e.xNext != nil {
+		e.xNext.xPrev = e.xPrev
+	}
+
+	e.xPrev, e.xNext = nil, nil
+}
+
+// 从Y轴链表中移除实体
+func (l *crossList) unlinkY(e *entity) {
+	if e.yPrev != nil {
+		e.yPrev.yNext = e.yNext
+	} else {
+		l.yHead = e.yNext
+	}
+
+	if e.yNext != nil {
+		e.yNext.yPrev = e.yPrev
+	}
+
+	e.yPrev, e.yNext = nil, nil
+}
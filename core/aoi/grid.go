@@ -0,0 +1,107 @@
+package aoi
+
+import "math"
+
+type cell struct {
+	x int64
+	y int64
+}
+
+type grid struct {
+	size  float64                       // 网格边长
+	cells map[cell]map[*entity]struct{} // 网格中的实体
+}
+
+// NewGrid 创建网格实现的AOI
+// 场景被划分为边长等于视野半径的网格，查找时仅遍历范围覆盖的网格，适用于实体分布较均匀的大场景
+func NewGrid(opts ...Option) AOI {
+	a := newArea(nil, opts...)
+
+	size := a.opts.radius
+	if size <= 0 {
+		size = defaultRadius
+	}
+
+	a.index = &grid{size: size, cells: make(map[cell]map[*entity]struct{})}
+
+	return a
+}
+
+// 定位坐标所在网格
+func (g *grid) locate(x, y float64) cell {
+	return cell{x: int64(math.Floor(x / g.size)), y: int64(math.Floor(y / g.size))}
+}
+
+// 插入实体
+func (g *grid) insert(e *entity) {
+	e.cell = g.locate(e.x, e.y)
+
+	entities, ok := g.cells[e.cell]
+	if !ok {
+		entities = make(map[*entity]struct{})
+		g.cells[e.cell] = entities
+	}
+
+	entities[e] = struct{}{}
+}
+
+// 移除实体
+func (g *grid) remove(e *entity) {
+	entities, ok := g.cells[e.cell]
+	if !ok {
+		return
+	}
+
+	delete(entities, e)
+
+	if len(entities) == 0 {
+		delete(g.cells, e.cell)
+	}
+}
+
+// 更新实体位置
+func (g *grid) update(e *entity, x, y float64) {
+	if c := g.locate(x, y); c == e.cell {
+		e.x, e.y = x, y
+		return
+	}
+
+	g.remove(e)
+	e.x, e.y = x, y
+	g.insert(e)
+}
+
+// 查找范围内的实体
+func (g *grid) search(x, y, radius float64, _ *entity, fn func(e *entity)) {
+	var (
+		min = g.locate(x-radius, y-radius)
+		max = g.locate(x+radius, y+radius)
+	)
+
+	// 范围覆盖的网格数多于已有网格数时，直接遍历已有网格
+	if float64(max.x-min.x+1)*float64(max.y-min.y+1) > float64(len(g.cells)) {
+		for c, entities := range g.cells {
+			if c.x < min.x || c.x > max.x || c.y < min.y || c.y > max.y {
+				continue
+			}
+
+			for e := range entities {
+				if within(x, y, e.x, e.y, radius) {
+					fn(e)
+				}
+			}
+		}
+
+		return
+	}
+
+	for cx := min.x; cx <= max.x; cx++ {
+		for cy := min.y; cy <= max.y; cy++ {
+			for e := range g.cells[cell{x: cx, y: cy}] {
+				if within(x, y, e.x, e.y, radius) {
+					fn(e)
+				}
+			}
+		}
+	}
+}
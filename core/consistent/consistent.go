@@ -0,0 +1,140 @@
+package consistent
+
+import (
+	"hash/crc32"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+const defaultReplicas = 160 // 默认虚拟节点数
+
+// Consistent 一致性哈希环实现
+type Consistent struct {
+	rw       sync.RWMutex
+	replicas int
+	hashes   []uint32
+	nodes    map[uint32]string
+	members  map[string]struct{}
+}
+
+func NewConsistent(replicas ...int) *Consistent {
+	c := &Consistent{
+		replicas: defaultReplicas,
+		nodes:    make(map[uint32]string),
+		members:  make(map[string]struct{}),
+	}
+
+	if len(replicas) > 0 && replicas[0] > 0 {
+		c.replicas = replicas[0]
+	}
+
+	return c
+}
+
+// Add 添加成员
+func (c *Consistent) Add(members ...string) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	for _, member := range members {
+		if _, ok := c.members[member]; ok {
+			continue
+		}
+
+		c.members[member] = struct{}{}
+
+		for i := range c.replicas {
+			h := c.hash(member + "#" + strconv.Itoa(i))
+			if _, ok := c.nodes[h]; ok {
+				continue
+			}
+
+			c.nodes[h] = member
+			c.hashes = append(c.hashes, h)
+		}
+	}
+
+	slices.Sort(c.hashes)
+}
+
+// Remove 移除成员
+func (c *Consistent) Remove(members ...string) {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	for _, member := range members {
+		if _, ok := c.members[member]; !ok {
+			continue
+		}
+
+		delete(c.members, member)
+
+		for i := range c.replicas {
+			h := c.hash(member + "#" + strconv.Itoa(i))
+			if m, ok := c.nodes[h]; ok && m == member {
+				delete(c.nodes, h)
+			}
+		}
+	}
+
+	c.hashes = c.hashes[:0]
+	for h := range c.nodes {
+		c.hashes = append(c.hashes, h)
+	}
+
+	slices.Sort(c.hashes)
+}
+
+// Replace 替换全部成员
+func (c *Consistent) Replace(members ...string) {
+	c.rw.Lock()
+	c.hashes = c.hashes[:0]
+	clear(c.nodes)
+	clear(c.members)
+	c.rw.Unlock()
+
+	c.Add(members...)
+}
+
+// Get 获取键所属的成员
+func (c *Consistent) Get(key string) (string, bool) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	if len(c.hashes) == 0 {
+		return "", false
+	}
+
+	h := c.hash(key)
+
+	i, _ := slices.BinarySearch(c.hashes, h)
+	if i == len(c.hashes) {
+		i = 0
+	}
+
+	return c.nodes[c.hashes[i]], true
+}
+
+// Has 检测是否存在成员
+func (c *Consistent) Has(member string) bool {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	_, ok := c.members[member]
+
+	return ok
+}
+
+// Len 获取成员数量
+func (c *Consistent) Len() int {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	return len(c.members)
+}
+
+// 计算哈希值
+func (c *Consistent) hash(key string) uint32 {
+	return crc32.ChecksumIEEE([]byte(key))
+}
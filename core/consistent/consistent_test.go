@@ -0,0 +1,66 @@
+package consistent_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dobyte/due/v2/core/consistent"
+)
+
+func TestConsistent_Get(t *testing.T) {
+	c := consistent.NewConsistent()
+	c.Add("node-1", "node-2", "node-3")
+
+	member, ok := c.Get("10001")
+	if !ok {
+		t.Fatal("not found member")
+	}
+
+	for range 10 {
+		if m, _ := c.Get("10001"); m != member {
+			t.Fatalf("inconsistent member: %s != %s", m, member)
+		}
+	}
+}
+
+func TestConsistent_Remove(t *testing.T) {
+	c := consistent.NewConsistent()
+	c.Add("node-1", "node-2", "node-3")
+
+	before := make(map[string]string, 1000)
+	for i := range 1000 {
+		key := strconv.Itoa(i)
+		before[key], _ = c.Get(key)
+	}
+
+	c.Remove("node-3")
+
+	moved := 0
+	for key, member := range before {
+		m, _ := c.Get(key)
+		if m == "node-3" {
+			t.Fatalf("removed member is still hit: %s", key)
+		}
+
+		if member != "node-3" && m != member {
+			moved++
+		}
+	}
+
+	if moved > 0 {
+		t.Fatalf("keys of remaining members moved: %d", moved)
+	}
+}
+
+func TestConsistent_Distribution(t *testing.T) {
+	c := consistent.NewConsistent()
+	c.Add("node-1", "node-2", "node-3", "node-4")
+
+	counts := make(map[string]int)
+	for i := range 10000 {
+		m, _ := c.Get(strconv.Itoa(i))
+		counts[m]++
+	}
+
+	t.Log(counts)
+}
@@ -29,9 +29,11 @@ var (
 	ErrConnectionNotOpened     = New("connection is not opened")
 	ErrConnectionNotHanged     = New("connection is not hanged")
 	ErrTooManyConnection       = New("too many connection")
+	ErrWriteQueueFull          = New("write queue is full")
 	ErrSeqOverflow             = New("seq overflow")
 	ErrRouteOverflow           = New("route overflow")
 	ErrMessageTooLarge         = New("message too large")
+	ErrInvalidHeader           = New("invalid header")
 	ErrInvalidDecoder          = New("invalid decoder")
 	ErrInvalidScanner          = New("invalid scanner")
 	ErrNoOperationPermission   = New("no operation permission")
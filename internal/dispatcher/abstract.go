@@ -11,6 +11,7 @@ type serviceEndpoint struct {
 	endpoint   *endpoint.Endpoint
 	weight     int
 	currWeight int
+	load       int
 }
 
 type abstract struct {
@@ -1,6 +1,7 @@
 package dispatcher
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/dobyte/due/v2/cluster"
@@ -99,6 +100,7 @@ func (d *Dispatcher) ReplaceServices(services ...*registry.ServiceInstance) {
 
 		endpoints[service.ID] = ep
 		instances[service.ID] = service
+		load := parseLoad(service)
 
 		for _, item := range service.Routes {
 			route, ok := routes[item.ID]
@@ -111,6 +113,7 @@ func (d *Dispatcher) ReplaceServices(services ...*registry.ServiceInstance) {
 				state:    service.State,
 				endpoint: ep,
 				weight:   service.Weight,
+				load:     load,
 			})
 		}
 
@@ -125,10 +128,17 @@ func (d *Dispatcher) ReplaceServices(services ...*registry.ServiceInstance) {
 				state:    service.State,
 				endpoint: ep,
 				weight:   service.Weight,
+				load:     load,
 			})
 		}
 	}
 
+	if d.dispatch == cluster.ConsistentHash {
+		for _, route := range routes {
+			route.buildRing()
+		}
+	}
+
 	d.rw.Lock()
 	d.routes = routes
 	d.events = events
@@ -136,3 +146,19 @@ func (d *Dispatcher) ReplaceServices(services ...*registry.ServiceInstance) {
 	d.instances = instances
 	d.rw.Unlock()
 }
+
+// 解析服务实例负载，未发布负载的实例视为零负载
+func parseLoad(service *registry.ServiceInstance) int {
+	value, ok := service.Metadata[cluster.LoadMetadataKey]
+	if !ok {
+		return 0
+	}
+
+	load, err := strconv.Atoi(value)
+	if err != nil {
+		log.Warnf("service load parse failed, insID: %s load: %s err: %v", service.ID, value, err)
+		return 0
+	}
+
+	return load
+}
@@ -196,6 +196,96 @@ func TestDispatcher_WeightRoundRobin(t *testing.T) {
 	}
 }
 
+func TestDispatcher_ConsistentHash(t *testing.T) {
+	instances := make([]*registry.ServiceInstance, 0, 5)
+	for i := 1; i <= 5; i++ {
+		instances = append(instances, &registry.ServiceInstance{
+			ID:       fmt.Sprintf("x%d", i),
+			Name:     fmt.Sprintf("node-%d", i),
+			Kind:     cluster.Node.String(),
+			Alias:    fmt.Sprintf("node-%d", i),
+			State:    cluster.Work.String(),
+			Endpoint: endpoint.NewEndpoint("grpc", fmt.Sprintf("127.0.0.1:%d", 8000+i), false).String(),
+			Routes:   []registry.Route{{ID: 1}},
+		})
+	}
+
+	dispatch := func(d *dispatcher.Dispatcher) map[int64]string {
+		route, err := d.FindRoute(1)
+		if err != nil {
+			t.Fatalf("find route failed: %v", err)
+		}
+
+		addrs := make(map[int64]string)
+		for uid := int64(1); uid <= 1000; uid++ {
+			ep, err := route.DispatchEndpoint(uid)
+			if err != nil {
+				t.Fatalf("dispatch endpoint failed: %v", err)
+			}
+
+			addrs[uid] = ep.Address()
+		}
+
+		return addrs
+	}
+
+	d := dispatcher.NewDispatcher(cluster.ConsistentHash)
+	d.ReplaceServices(instances...)
+	before := dispatch(d)
+
+	// 同一用户多次分发结果应保持一致
+	for uid, addr := range dispatch(d) {
+		if before[uid] != addr {
+			t.Fatalf("dispatch result of uid %d changed, want: %s got: %s", uid, before[uid], addr)
+		}
+	}
+
+	// 移除一个实例后，仅原属于该实例的用户发生迁移
+	removed := "127.0.0.1:8003"
+	d.ReplaceServices(append(instances[:2:2], instances[3:]...)...)
+
+	for uid, addr := range dispatch(d) {
+		if before[uid] != removed && before[uid] != addr {
+			t.Fatalf("uid %d moved unexpectedly, want: %s got: %s", uid, before[uid], addr)
+		}
+	}
+}
+
+func TestDispatcher_LeastLoad(t *testing.T) {
+	instances := make([]*registry.ServiceInstance, 0, 3)
+	for i, load := range []string{"30", "10", "20"} {
+		instances = append(instances, &registry.ServiceInstance{
+			ID:       fmt.Sprintf("x%d", i),
+			Name:     fmt.Sprintf("node-%d", i),
+			Kind:     cluster.Node.String(),
+			Alias:    fmt.Sprintf("node-%d", i),
+			State:    cluster.Work.String(),
+			Endpoint: endpoint.NewEndpoint("grpc", fmt.Sprintf("127.0.0.1:%d", 8000+i), false).String(),
+			Routes:   []registry.Route{{ID: 1}},
+			Metadata: map[string]string{cluster.LoadMetadataKey: load},
+		})
+	}
+
+	d := dispatcher.NewDispatcher(cluster.LeastLoad)
+	d.ReplaceServices(instances...)
+
+	route, err := d.FindRoute(1)
+	if err != nil {
+		t.Fatalf("find route failed: %v", err)
+	}
+
+	for range 10 {
+		ep, err := route.FindEndpoint()
+		if err != nil {
+			t.Fatalf("find endpoint failed: %v", err)
+		}
+
+		if ep.Address() != "127.0.0.1:8001" {
+			t.Fatalf("dispatch to %s, want: 127.0.0.1:8001", ep.Address())
+		}
+	}
+}
+
 func BenchmarkDispatcher_WeightRoundRobin(b *testing.B) {
 	var (
 		// 创建测试服务实例
@@ -2,21 +2,24 @@ package dispatcher
 
 import (
 	"math/rand/v2"
+	"strconv"
 	"sync/atomic"
 
 	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/core/consistent"
 	"github.com/dobyte/due/v2/core/endpoint"
 	"github.com/dobyte/due/v2/errors"
 )
 
 type Route struct {
 	abstract
-	id         int32         // 路由ID
-	group      string        // 路由所属组
-	stateful   bool          // 是否有状态
-	internal   bool          // 是否内部路由
-	counter    atomic.Uint64 // 轮询计数器
-	dispatcher *Dispatcher   // 分发器
+	id         int32                  // 路由ID
+	group      string                 // 路由所属组
+	stateful   bool                   // 是否有状态
+	internal   bool                   // 是否内部路由
+	counter    atomic.Uint64          // 轮询计数器
+	ring       *consistent.Consistent // 一致性哈希环，仅一致性哈希策略使用
+	dispatcher *Dispatcher            // 分发器
 }
 
 func newRoute(dispatcher *Dispatcher, id int32, group string, stateful, internal bool) *Route {
@@ -53,19 +56,29 @@ func (r *Route) Internal() bool {
 // FindEndpoint 查询路由服务端点
 func (r *Route) FindEndpoint(insID ...string) (*endpoint.Endpoint, error) {
 	if len(insID) == 0 || insID[0] == "" {
-		switch r.dispatcher.dispatch {
-		case cluster.RoundRobin:
-			return r.roundRobinDispatch()
-		case cluster.WeightRoundRobin:
-			return r.weightRoundRobinDispatch()
-		default:
-			return r.randomDispatch()
-		}
+		return r.DispatchEndpoint(0)
 	}
 
 	return r.directDispatch(insID[0])
 }
 
+// DispatchEndpoint 按分发策略分配路由服务端点
+// 一致性哈希策略下按用户ID分配，用户ID为0时退化为随机分配
+func (r *Route) DispatchEndpoint(uid int64) (*endpoint.Endpoint, error) {
+	switch r.dispatcher.dispatch {
+	case cluster.RoundRobin:
+		return r.roundRobinDispatch()
+	case cluster.WeightRoundRobin:
+		return r.weightRoundRobinDispatch()
+	case cluster.ConsistentHash:
+		return r.consistentHashDispatch(uid)
+	case cluster.LeastLoad:
+		return r.leastLoadDispatch()
+	default:
+		return r.randomDispatch()
+	}
+}
+
 // 直接分配
 func (r *Route) directDispatch(insID string) (*endpoint.Endpoint, error) {
 	sep, ok := r.endpoints2[insID]
@@ -122,3 +135,54 @@ func (r *Route) weightRoundRobinDispatch() (*endpoint.Endpoint, error) {
 
 	return selected.endpoint, nil
 }
+
+// 一致性哈希分配
+func (r *Route) consistentHashDispatch(uid int64) (*endpoint.Endpoint, error) {
+	if uid == 0 || r.ring == nil {
+		return r.randomDispatch()
+	}
+
+	insID, ok := r.ring.Get(strconv.FormatInt(uid, 10))
+	if !ok {
+		return nil, errors.ErrNotFoundEndpoint
+	}
+
+	se, ok := r.endpoints4[insID]
+	if !ok {
+		return nil, errors.ErrNotFoundEndpoint
+	}
+
+	return se.endpoint, nil
+}
+
+// 最小负载分配，负载相同时轮询分配
+func (r *Route) leastLoadDispatch() (*endpoint.Endpoint, error) {
+	n := len(r.endpoints3)
+	if n == 0 {
+		return nil, errors.ErrNotFoundEndpoint
+	}
+
+	var (
+		offset   = int(r.counter.Add(1) % uint64(n))
+		selected *serviceEndpoint
+	)
+
+	for i := range n {
+		se := r.endpoints3[(offset+i)%n]
+
+		if selected == nil || se.load < selected.load {
+			selected = se
+		}
+	}
+
+	return selected.endpoint, nil
+}
+
+// 构建一致性哈希环
+func (r *Route) buildRing() {
+	r.ring = consistent.NewConsistent()
+
+	for _, se := range r.endpoints3 {
+		r.ring.Add(se.insID)
+	}
+}
@@ -233,6 +233,86 @@ func (l *GateLinker) Stat(ctx context.Context, kind session.Kind) (int64, error)
 	return total, err
 }
 
+// StatChannel 统计频道订阅者数量
+func (l *GateLinker) StatChannel(ctx context.Context, channel string) (int64, error) {
+	total := int64(0)
+	eg, ctx := errgroup.WithContext(ctx)
+
+	l.dispatcher.VisitEndpoints(func(_ string, ep *endpoint.Endpoint) bool {
+		eg.Go(func() error {
+			client, err := l.builder.Build(ep.Address())
+			if err != nil {
+				return err
+			}
+
+			n, err := client.StatChannel(ctx, channel)
+			if err != nil {
+				return err
+			}
+
+			atomic.AddInt64(&total, n)
+
+			return nil
+		})
+
+		return true
+	})
+
+	err := eg.Wait()
+
+	if total > 0 {
+		return total, nil
+	}
+
+	return total, err
+}
+
+// GetChannels 获取订阅的频道
+func (l *GateLinker) GetChannels(ctx context.Context, args *GetChannelsArgs) ([]string, error) {
+	switch args.Kind {
+	case session.Conn:
+		return l.doDirectGetChannels(ctx, args.GID, args.Kind, args.Target)
+	case session.User:
+		if args.GID == "" {
+			return l.doIndirectGetChannels(ctx, args.Target)
+		} else {
+			return l.doDirectGetChannels(ctx, args.GID, args.Kind, args.Target)
+		}
+	default:
+		return nil, errors.ErrInvalidSessionKind
+	}
+}
+
+// 直接获取订阅的频道
+func (l *GateLinker) doDirectGetChannels(ctx context.Context, gid string, kind session.Kind, target int64) ([]string, error) {
+	client, err := l.doBuildClient(gid)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, miss, err := client.GetChannels(ctx, kind, target)
+	if err == nil && miss {
+		err = errors.ErrNotFoundSession
+	}
+
+	return channels, err
+}
+
+// 间接获取订阅的频道
+func (l *GateLinker) doIndirectGetChannels(ctx context.Context, uid int64) ([]string, error) {
+	v, err := l.doRPC(ctx, uid, func(client *gate.Client) (bool, any, error) {
+		channels, miss, err := client.GetChannels(ctx, session.User, uid)
+		return miss, channels, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	channels, _ := v.([]string)
+
+	return channels, nil
+}
+
 // IsOnline 检测是否在线
 func (l *GateLinker) IsOnline(ctx context.Context, args *IsOnlineArgs) (bool, error) {
 	switch args.Kind {
@@ -747,18 +827,53 @@ func (l *GateLinker) doBuildClient(gid string) (*gate.Client, error) {
 
 // PackMessage 打包消息
 func (l *GateLinker) PackMessage(message *Message, encrypt bool) (buffer.Buffer, error) {
-	buf, err := l.PackBuffer(message.Data, encrypt)
+	buf, compressed, err := l.toBuffer(message.Data, encrypt)
 	if err != nil {
 		return nil, err
 	}
 
 	return packet.PackBuffer(&packet.Message{
-		Seq:    message.Seq,
-		Route:  message.Route,
-		Buffer: buf,
+		Seq:        message.Seq,
+		Route:      message.Route,
+		Buffer:     buf,
+		Headers:    message.Headers,
+		Compressed: compressed,
 	})
 }
 
+// 消息转buffer，需加密时先压缩再加密，返回消息内容是否已压缩
+func (l *GateLinker) toBuffer(message any, encrypt bool) ([]byte, bool, error) {
+	if !encrypt || l.opts.Encryptor == nil {
+		buf, err := l.PackBuffer(message, false)
+		return buf, false, err
+	}
+
+	if message == nil {
+		return nil, false, nil
+	}
+
+	if v, ok := message.([]byte); ok {
+		return v, false, nil
+	}
+
+	data, err := l.opts.Codec.Marshal(message)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, compressed, err := packet.CompressBuffer(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err = l.opts.Encryptor.Encrypt(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, compressed, nil
+}
+
 // PackBuffer 消息转buffer
 func (l *GateLinker) PackBuffer(message any, encrypt bool) ([]byte, error) {
 	if message == nil {
@@ -0,0 +1,32 @@
+package link
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/metrics"
+)
+
+const unknownRoute = "unknown" // 未注册路由的统一标签，避免客户端通过任意路由号制造无限的时间序列
+
+var (
+	rpcRequests = metrics.NewCounter("due_linker_rpc_requests_total", "Total number of RPC requests sent to nodes.", "route")
+	rpcFailures = metrics.NewCounter("due_linker_rpc_failures_total", "Total number of RPC requests to nodes that returned an error.", "route")
+	rpcDuration = metrics.NewHistogram("due_linker_rpc_duration_seconds", "Duration of RPC requests sent to nodes.", nil, "route")
+)
+
+// 统计节点RPC调用
+func observeRPC(route int32, start time.Time, err error) {
+	label := strconv.Itoa(int(route))
+	if errors.Is(err, errors.ErrNotFoundRoute) {
+		label = unknownRoute
+	}
+
+	rpcRequests.Add(1, label)
+	rpcDuration.Observe(time.Since(start).Seconds(), label)
+
+	if err != nil {
+		rpcFailures.Add(1, label)
+	}
+}
@@ -2,6 +2,7 @@ package link
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/packet"
 	"github.com/dobyte/due/v2/registry"
+	"github.com/dobyte/due/v2/trace"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -130,20 +132,18 @@ func (l *NodeLinker) Unbind(ctx context.Context, uid int64, name, nid string) er
 }
 
 // Deliver 投递消息给节点处理
-func (l *NodeLinker) Deliver(ctx context.Context, args *DeliverArgs) error {
-	var message []byte
+func (l *NodeLinker) Deliver(ctx context.Context, args *DeliverArgs) (err error) {
+	ctx, span := trace.Start(ctx, "link.deliver")
+	span.SetAttribute("route", args.Route)
+	span.SetAttribute("nid", args.NID)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
 
-	switch msg := args.Message.(type) {
-	case []byte:
-		message = msg
-	case *Message:
-		if m, err := l.doPackMessage(msg, false); err != nil {
-			return err
-		} else {
-			message = m
-		}
-	default:
-		return errors.ErrInvalidMessage
+	message, err := l.doToMessage(args.Message)
+	if err != nil {
+		return err
 	}
 
 	if args.NID != "" {
@@ -165,6 +165,73 @@ func (l *NodeLinker) Deliver(ctx context.Context, args *DeliverArgs) error {
 	}
 }
 
+// Tell 投递消息给Actor处理
+func (l *NodeLinker) Tell(ctx context.Context, args *TellArgs) error {
+	message, err := l.doToMessage(args.Message)
+	if err != nil {
+		return err
+	}
+
+	nid := args.NID
+
+	if nid == "" {
+		kind, id, ok := strings.Cut(args.Target, "/")
+		if !ok {
+			return errors.ErrInvalidArgument
+		}
+
+		if nid, err = l.LocateActor(ctx, kind, id); err != nil {
+			return err
+		}
+	}
+
+	client, err := l.doBuildClient(nid)
+	if err != nil {
+		return err
+	}
+
+	return client.Tell(ctx, args.CID, args.UID, args.Target, args.Source, message)
+}
+
+// BindActor 绑定Actor所在节点
+func (l *NodeLinker) BindActor(ctx context.Context, kind, id, nid string) error {
+	locator, ok := l.opts.Locator.(locate.ActorLocator)
+	if !ok {
+		return errors.ErrNotFoundLocator
+	}
+
+	return locator.BindActor(ctx, kind, id, nid)
+}
+
+// UnbindActor 解绑Actor所在节点
+func (l *NodeLinker) UnbindActor(ctx context.Context, kind, id, nid string) error {
+	locator, ok := l.opts.Locator.(locate.ActorLocator)
+	if !ok {
+		return errors.ErrNotFoundLocator
+	}
+
+	return locator.UnbindActor(ctx, kind, id, nid)
+}
+
+// LocateActor 定位Actor所在节点
+func (l *NodeLinker) LocateActor(ctx context.Context, kind, id string) (string, error) {
+	locator, ok := l.opts.Locator.(locate.ActorLocator)
+	if !ok {
+		return "", errors.ErrNotFoundLocator
+	}
+
+	nid, err := locator.LocateActor(ctx, kind, id)
+	if err != nil {
+		return "", err
+	}
+
+	if nid == "" {
+		return "", errors.ErrNotFoundActor
+	}
+
+	return nid, nil
+}
+
 // Trigger 触发事件
 func (l *NodeLinker) Trigger(ctx context.Context, args *TriggerArgs) error {
 	event, err := l.dispatcher.FindEvent(int(args.Event))
@@ -237,16 +304,16 @@ func (l *NodeLinker) SetState(ctx context.Context, nid string, state cluster.Sta
 }
 
 // 执行节点RPC调用
-func (l *NodeLinker) doRPC(ctx context.Context, routeID int32, uid int64, fn func(ctx context.Context, client *node.Client) (bool, any, error)) (any, error) {
+func (l *NodeLinker) doRPC(ctx context.Context, routeID int32, uid int64, fn func(ctx context.Context, client *node.Client) (bool, any, error)) (reply any, err error) {
+	defer func(start time.Time) { observeRPC(routeID, start, err) }(time.Now())
+
 	var (
-		err       error
 		nid       string
 		prev      string
 		route     *dispatcher.Route
 		client    *node.Client
 		ep        *endpoint.Endpoint
 		continued bool
-		reply     any
 	)
 
 	if route, err = l.dispatcher.FindRoute(routeID); err != nil {
@@ -268,7 +335,11 @@ func (l *NodeLinker) doRPC(ctx context.Context, routeID int32, uid int64, fn fun
 			prev = nid
 		}
 
-		ep, err = route.FindEndpoint(nid)
+		if route.Stateful() {
+			ep, err = route.FindEndpoint(nid)
+		} else {
+			ep, err = route.DispatchEndpoint(uid)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -306,6 +377,18 @@ func (l *NodeLinker) doBuildClient(nid string) (*node.Client, error) {
 	return l.builder.Build(ep.Address())
 }
 
+// 转换投递的消息
+func (l *NodeLinker) doToMessage(message any) ([]byte, error) {
+	switch msg := message.(type) {
+	case []byte:
+		return msg, nil
+	case *Message:
+		return l.doPackMessage(msg, false)
+	default:
+		return nil, errors.ErrInvalidMessage
+	}
+}
+
 // 打包消息
 func (l *NodeLinker) doPackMessage(message *Message, encrypt bool) ([]byte, error) {
 	buffer, err := l.toBuffer(message.Data, encrypt)
@@ -314,9 +397,10 @@ func (l *NodeLinker) doPackMessage(message *Message, encrypt bool) ([]byte, erro
 	}
 
 	return packet.PackMessage(&packet.Message{
-		Seq:    message.Seq,
-		Route:  message.Route,
-		Buffer: buffer,
+		Seq:     message.Seq,
+		Route:   message.Route,
+		Buffer:  buffer,
+		Headers: message.Headers,
 	})
 }
 
@@ -15,6 +15,7 @@ type (
 	PublishArgs     = cluster.PublishArgs
 	SubscribeArgs   = cluster.SubscribeArgs
 	UnsubscribeArgs = cluster.UnsubscribeArgs
+	GetChannelsArgs = cluster.GetChannelsArgs
 )
 
 type DeliverArgs struct {
@@ -25,6 +26,15 @@ type DeliverArgs struct {
 	Message any    // 消息
 }
 
+type TellArgs struct {
+	NID     string // 接收节点。存在接收节点时，消息会直接投递给接收节点；不存在接收节点时，系统定位Actor所在节点，然后投递。
+	CID     int64  // 连接ID
+	UID     int64  // 用户ID
+	Target  string // 接收Actor的PID
+	Source  string // 来源Actor的PID
+	Message any    // 消息
+}
+
 type TriggerArgs struct {
 	Event cluster.Event // 事件
 	CID   int64         // 连接ID
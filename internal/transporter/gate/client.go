@@ -97,6 +97,44 @@ func (c *Client) Stat(ctx context.Context, kind session.Kind) (int64, error) {
 	return int64(total), err
 }
 
+// StatChannel 统计频道订阅者数量
+func (c *Client) StatChannel(ctx context.Context, channel string) (int64, error) {
+	seq := c.doGenSequence()
+
+	buf := protocol.EncodeStatChannelReq(seq, channel)
+
+	res, err := c.cli.Call(ctx, seq, buf)
+	if err != nil {
+		return 0, err
+	}
+
+	code, total, err := protocol.DecodeStatChannelRes(res)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(total), codes.CodeToError(code)
+}
+
+// GetChannels 获取订阅的频道
+func (c *Client) GetChannels(ctx context.Context, kind session.Kind, target int64) ([]string, bool, error) {
+	seq := c.doGenSequence()
+
+	buf := protocol.EncodeGetChannelsReq(seq, kind, target)
+
+	res, err := c.cli.Call(ctx, seq, buf)
+	if err != nil {
+		return nil, false, err
+	}
+
+	code, channels, err := protocol.DecodeGetChannelsRes(res)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return channels, code == codes.NotFoundSession, nil
+}
+
 // IsOnline 检测是否在线
 func (c *Client) IsOnline(ctx context.Context, kind session.Kind, target int64) (bool, bool, error) {
 	seq := c.doGenSequence()
@@ -18,6 +18,10 @@ type Provider interface {
 	IsOnline(ctx context.Context, kind session.Kind, target int64) (isOnline bool, err error)
 	// Stat 统计会话总数
 	Stat(ctx context.Context, kind session.Kind) (total int64, err error)
+	// StatChannel 统计频道订阅者数量
+	StatChannel(ctx context.Context, channel string) (total int64, err error)
+	// GetChannels 获取订阅的频道
+	GetChannels(ctx context.Context, kind session.Kind, target int64) (channels []string, err error)
 	// Disconnect 断开连接
 	Disconnect(ctx context.Context, kind session.Kind, target int64, force bool) error
 	// Push 发送消息
@@ -33,6 +33,8 @@ func (s *Server) init() {
 	s.RegisterHandler(route.Unbind, s.unbind)
 	s.RegisterHandler(route.GetIP, s.getIP)
 	s.RegisterHandler(route.Stat, s.stat)
+	s.RegisterHandler(route.StatChannel, s.statChannel)
+	s.RegisterHandler(route.GetChannels, s.getChannels)
 	s.RegisterHandler(route.IsOnline, s.isOnline)
 	s.RegisterHandler(route.Disconnect, s.disconnect)
 	s.RegisterHandler(route.Push, s.push)
@@ -101,6 +103,34 @@ func (s *Server) stat(conn *server.Conn, data []byte) error {
 	}
 }
 
+// 统计频道订阅者数量
+func (s *Server) statChannel(conn *server.Conn, data []byte) error {
+	seq, channel, err := protocol.DecodeStatChannelReq(data)
+	if err != nil {
+		return err
+	}
+
+	if total, err := s.provider.StatChannel(context.Background(), channel); seq == 0 {
+		return err
+	} else {
+		return conn.Send(protocol.EncodeStatChannelRes(seq, codes.ErrorToCode(err), uint64(total)))
+	}
+}
+
+// 获取订阅的频道
+func (s *Server) getChannels(conn *server.Conn, data []byte) error {
+	seq, kind, target, err := protocol.DecodeGetChannelsReq(data)
+	if err != nil {
+		return err
+	}
+
+	if channels, err := s.provider.GetChannels(context.Background(), kind, target); seq == 0 {
+		return err
+	} else {
+		return conn.Send(protocol.EncodeGetChannelsRes(seq, codes.ErrorToCode(err), channels...))
+	}
+}
+
 // 检测用户是否在线
 func (s *Server) isOnline(conn *server.Conn, data []byte) error {
 	seq, kind, target, err := protocol.DecodeIsOnlineReq(data)
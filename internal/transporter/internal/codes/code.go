@@ -8,6 +8,7 @@ const (
 	OK              uint16 = iota // 成功
 	NotFoundSession               // 未找到会话连接
 	InternalError                 // 内部错误
+	NotFoundActor                 // 未找到Actor
 )
 
 // ErrorToCode 错误转错误码
@@ -17,6 +18,8 @@ func ErrorToCode(err error) uint16 {
 		return OK
 	case errors.Is(err, errors.ErrNotFoundSession):
 		return NotFoundSession
+	case errors.Is(err, errors.ErrNotFoundActor):
+		return NotFoundActor
 	default:
 		return InternalError
 	}
@@ -28,6 +31,8 @@ func CodeToError(code uint16) error {
 		return nil
 	case NotFoundSession:
 		return errors.ErrNotFoundSession
+	case NotFoundActor:
+		return errors.ErrNotFoundActor
 	default:
 		return errors.ErrUnknownError
 	}
@@ -13,6 +13,13 @@ const (
 	heartbeatBit uint8 = 1 << 7 // 心跳标识位
 )
 
+// 数据包头信息中的扩展字段标识位
+// 扩展字段仅在标识位存在时写入，未携带扩展字段的数据包与旧版本协议保持一致
+const (
+	timeoutBit uint8 = 1 << 0 // 超时时长标识位
+	traceBit   uint8 = 1 << 1 // 链路上下文标识位
+)
+
 const (
 	b8 = 1 << iota
 	b16
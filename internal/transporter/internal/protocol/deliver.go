@@ -5,6 +5,7 @@ import (
 	"github.com/dobyte/due/v2/core/buffer"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/internal/transporter/internal/route"
+	"github.com/dobyte/due/v2/trace"
 	"io"
 )
 
@@ -14,22 +15,70 @@ const (
 )
 
 // EncodeDeliverReq 编码投递消息请求
-// 协议：size + header + route + seq + cid + uid + <message packet>
-func EncodeDeliverReq(seq uint64, cid int64, uid int64, message []byte) buffer.Buffer {
+// 协议：size + header + route + seq + cid + uid + [timeout] + [trace] + <message packet>
+// timeout为距截止时间的剩余纳秒数，仅在大于0时写入并在header中置timeoutBit；使用相对时长以避免节点间时钟偏差影响超时判断
+// trace为链路上下文，仅在链路上下文有效时写入并在header中置traceBit
+func EncodeDeliverReq(seq uint64, cid int64, uid int64, timeout int64, sc trace.SpanContext, message []byte) buffer.Buffer {
+	header, size := dataBit, deliverReqBytes
+	if timeout > 0 {
+		header |= timeoutBit
+		size += b64
+	}
+
+	if sc.IsValid() {
+		header |= traceBit
+		size += trace.SpanContextSize
+	}
+
 	buf := buffer.NewNocopyBuffer()
-	writer := buf.Malloc(deliverReqBytes)
-	writer.WriteUint32s(binary.BigEndian, uint32(deliverReqBytes-defaultSizeBytes+len(message)))
-	writer.WriteUint8s(dataBit)
+	writer := buf.Malloc(size)
+	writer.WriteUint32s(binary.BigEndian, uint32(size-defaultSizeBytes+len(message)))
+	writer.WriteUint8s(header)
 	writer.WriteUint8s(route.Deliver)
 	writer.WriteUint64s(binary.BigEndian, seq)
 	writer.WriteInt64s(binary.BigEndian, cid, uid)
+
+	if timeout > 0 {
+		writer.WriteInt64s(binary.BigEndian, timeout)
+	}
+
+	if sc.IsValid() {
+		writer.WriteBytes(sc.Bytes()...)
+	}
+
 	buf.Mount(message)
 
 	return buf
 }
 
 // DecodeDeliverReq 解码投递消息请求
-func DecodeDeliverReq(data []byte) (seq uint64, cid int64, uid int64, message []byte, err error) {
+// 协议：size + header + route + seq + cid + uid + [timeout] + [trace] + <message packet>
+// header中存在未知的标识位时视为无效消息
+func DecodeDeliverReq(data []byte) (seq uint64, cid int64, uid int64, timeout int64, sc trace.SpanContext, message []byte, err error) {
+	if len(data) < deliverReqBytes {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	header, size := data[defaultSizeBytes], deliverReqBytes
+	if header&^(timeoutBit|traceBit) != dataBit {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	if header&timeoutBit != 0 {
+		size += b64
+	}
+
+	if header&traceBit != 0 {
+		size += trace.SpanContextSize
+	}
+
+	if len(data) < size {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
 	reader := buffer.NewReader(data)
 
 	if _, err = reader.Seek(defaultSizeBytes+defaultHeaderBytes+defaultRouteBytes, io.SeekStart); err != nil {
@@ -48,7 +97,17 @@ func DecodeDeliverReq(data []byte) (seq uint64, cid int64, uid int64, message []
 		return
 	}
 
-	message = data[deliverReqBytes:]
+	if header&timeoutBit != 0 {
+		if timeout, err = reader.ReadInt64(binary.BigEndian); err != nil {
+			return
+		}
+	}
+
+	if header&traceBit != 0 {
+		sc, _ = trace.SpanContextFromBytes(data[size-trace.SpanContextSize : size])
+	}
+
+	message = data[size:]
 
 	return
 }
@@ -1,29 +1,105 @@
 package protocol_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
 	"github.com/dobyte/due/v2/internal/transporter/internal/protocol"
+	"github.com/dobyte/due/v2/internal/transporter/internal/route"
+	"github.com/dobyte/due/v2/trace"
 	"testing"
+	"time"
 )
 
 func TestEncodeDeliverReq(t *testing.T) {
-	buffer := protocol.EncodeDeliverReq(1, 2, 3, []byte("hello world"))
+	buffer := protocol.EncodeDeliverReq(1, 2, 3, 4, trace.SpanContext{}, []byte("hello world"))
 
 	t.Log(buffer.Bytes())
 }
 
 func TestDecodeDeliverReq(t *testing.T) {
-	buffer := protocol.EncodeDeliverReq(1, 2, 3, []byte("hello world"))
+	parent, _ := trace.ParseSpanContext("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	buffer := protocol.EncodeDeliverReq(1, 2, 3, int64(time.Second), parent, []byte("hello world"))
 
-	seq, cid, uid, message, err := protocol.DecodeDeliverReq(buffer.Bytes())
+	seq, cid, uid, timeout, sc, message, err := protocol.DecodeDeliverReq(buffer.Bytes())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	t.Logf("seq: %v", seq)
-	t.Logf("cid: %v", cid)
-	t.Logf("uid: %v", uid)
-	t.Logf("message: %v", string(message))
+	if seq != 1 || cid != 2 || uid != 3 {
+		t.Fatalf("unexpected header, seq: %d cid: %d uid: %d", seq, cid, uid)
+	}
+
+	if timeout != int64(time.Second) {
+		t.Fatalf("timeout mismatch, want: %d got: %d", int64(time.Second), timeout)
+	}
+
+	if sc != parent {
+		t.Fatalf("trace mismatch, want: %s got: %s", parent, sc)
+	}
+
+	if string(message) != "hello world" {
+		t.Fatalf("message mismatch, got: %s", message)
+	}
+
+	if _, _, _, _, _, _, err = protocol.DecodeDeliverReq(buffer.Bytes()[:10]); err == nil {
+		t.Fatal("truncated request should be rejected")
+	}
+}
+
+func TestDecodeDeliverReq_NoTrace(t *testing.T) {
+	buffer := protocol.EncodeDeliverReq(0, 2, 3, 0, trace.SpanContext{}, nil)
+
+	_, _, _, timeout, sc, message, err := protocol.DecodeDeliverReq(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if timeout != 0 || sc.IsValid() || len(message) != 0 {
+		t.Fatalf("unexpected request, timeout: %d trace: %s message: %v", timeout, sc, message)
+	}
+}
+
+func TestDecodeDeliverReq_Header(t *testing.T) {
+	with := protocol.EncodeDeliverReq(1, 2, 3, int64(time.Second), trace.SpanContext{}, nil).Bytes()
+	without := protocol.EncodeDeliverReq(1, 2, 3, 0, trace.SpanContext{}, nil).Bytes()
+
+	// 未设置超时时长时不写入超时字段，头信息与旧版本协议一致
+	if len(with)-len(without) != 8 || with[4] == without[4] || without[4] != 0 {
+		t.Fatalf("timeout is not signaled by header, with: %v without: %v", with[:5], without[:5])
+	}
+
+	unknown := append([]byte(nil), without...)
+	unknown[4] |= 1 << 6
+
+	if _, _, _, _, _, _, err := protocol.DecodeDeliverReq(unknown); !errors.Is(err, errors.ErrInvalidMessage) {
+		t.Fatalf("unknown header flag should be rejected, err: %v", err)
+	}
+}
+
+func TestDecodeDeliverReq_Legacy(t *testing.T) {
+	// 旧版本协议：size + header + route + seq + cid + uid + <message packet>
+	data := binary.BigEndian.AppendUint32(nil, 1+1+8+8+8+11)
+	data = append(data, 0, route.Deliver)
+	data = binary.BigEndian.AppendUint64(data, 1)
+	data = binary.BigEndian.AppendUint64(data, 2)
+	data = binary.BigEndian.AppendUint64(data, 3)
+	data = append(data, "hello world"...)
+
+	seq, cid, uid, timeout, sc, message, err := protocol.DecodeDeliverReq(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seq != 1 || cid != 2 || uid != 3 || timeout != 0 || sc.IsValid() || string(message) != "hello world" {
+		t.Fatalf("unexpected request, seq: %d cid: %d uid: %d timeout: %d trace: %s message: %s", seq, cid, uid, timeout, sc, message)
+	}
+
+	// 未携带扩展字段时编码结果与旧版本协议一致
+	if buffer := protocol.EncodeDeliverReq(1, 2, 3, 0, trace.SpanContext{}, []byte("hello world")); !bytes.Equal(buffer.Bytes(), data) {
+		t.Fatalf("encoded request mismatch legacy layout, want: %v got: %v", data, buffer.Bytes())
+	}
 }
 
 func TestEncodeDeliverRes(t *testing.T) {
@@ -33,12 +109,14 @@ func TestEncodeDeliverRes(t *testing.T) {
 }
 
 func TestDecodeDeliverRes(t *testing.T) {
-	buffer := protocol.EncodePushRes(1, codes.OK)
+	buffer := protocol.EncodeDeliverRes(1, codes.NotFoundSession)
 
 	code, err := protocol.DecodeDeliverRes(buffer.Bytes())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	t.Logf("code: %v", code)
+	if code != codes.NotFoundSession {
+		t.Fatalf("code mismatch, want: %d got: %d", codes.NotFoundSession, code)
+	}
 }
@@ -0,0 +1,131 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/dobyte/due/v2/core/buffer"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
+	"github.com/dobyte/due/v2/internal/transporter/internal/route"
+	"github.com/dobyte/due/v2/session"
+)
+
+const (
+	getChannelsReqBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + b8 + b64
+	getChannelsResBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + defaultCodeBytes
+)
+
+// EncodeGetChannelsReq 编码获取订阅的频道请求
+// 协议：size + header + route + seq + session kind + target
+func EncodeGetChannelsReq(seq uint64, kind session.Kind, target int64) buffer.Buffer {
+	buf := buffer.NewNocopyBuffer()
+	writer := buf.Malloc(getChannelsReqBytes)
+	writer.WriteUint32s(binary.BigEndian, uint32(getChannelsReqBytes-defaultSizeBytes))
+	writer.WriteUint8s(dataBit)
+	writer.WriteUint8s(route.GetChannels)
+	writer.WriteUint64s(binary.BigEndian, seq)
+	writer.WriteUint8s(uint8(kind))
+	writer.WriteInt64s(binary.BigEndian, target)
+
+	return buf
+}
+
+// DecodeGetChannelsReq 解码获取订阅的频道请求
+// 协议：size + header + route + seq + session kind + target
+func DecodeGetChannelsReq(data []byte) (seq uint64, kind session.Kind, target int64, err error) {
+	if len(data) != getChannelsReqBytes {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	reader := buffer.NewReader(data)
+
+	if _, err = reader.Seek(defaultSizeBytes+defaultHeaderBytes+defaultRouteBytes, io.SeekStart); err != nil {
+		return
+	}
+
+	if seq, err = reader.ReadUint64(binary.BigEndian); err != nil {
+		return
+	}
+
+	var k uint8
+	if k, err = reader.ReadUint8(); err != nil {
+		return
+	} else {
+		kind = session.Kind(k)
+	}
+
+	if target, err = reader.ReadInt64(binary.BigEndian); err != nil {
+		return
+	}
+
+	return
+}
+
+// EncodeGetChannelsRes 编码获取订阅的频道响应（单个频道名最长65535字节）
+// 协议：size + header + route + seq + code + [channel size + channel]...
+func EncodeGetChannelsRes(seq uint64, code uint16, channels ...string) buffer.Buffer {
+	size := getChannelsResBytes
+	if code == codes.OK {
+		for _, channel := range channels {
+			size += b16 + len(channel)
+		}
+	}
+
+	buf := buffer.NewNocopyBuffer()
+	writer := buf.Malloc(size)
+	writer.WriteUint32s(binary.BigEndian, uint32(size-defaultSizeBytes))
+	writer.WriteUint8s(dataBit)
+	writer.WriteUint8s(route.GetChannels)
+	writer.WriteUint64s(binary.BigEndian, seq)
+	writer.WriteUint16s(binary.BigEndian, code)
+
+	if code == codes.OK {
+		for _, channel := range channels {
+			writer.WriteUint16s(binary.BigEndian, uint16(len(channel)))
+			writer.WriteString(channel)
+		}
+	}
+
+	return buf
+}
+
+// DecodeGetChannelsRes 解码获取订阅的频道响应
+// 协议：size + header + route + seq + code + [channel size + channel]...
+func DecodeGetChannelsRes(data []byte) (code uint16, channels []string, err error) {
+	if len(data) < getChannelsResBytes {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	reader := buffer.NewReader(data)
+
+	if _, err = reader.Seek(defaultSizeBytes+defaultHeaderBytes+defaultRouteBytes+defaultSeqBytes, io.SeekStart); err != nil {
+		return
+	}
+
+	if code, err = reader.ReadUint16(binary.BigEndian); err != nil {
+		return
+	}
+
+	for offset := getChannelsResBytes; offset < len(data); {
+		if offset+b16 > len(data) {
+			err = errors.ErrInvalidMessage
+			return
+		}
+
+		n := int(binary.BigEndian.Uint16(data[offset:]))
+		offset += b16
+
+		if offset+n > len(data) {
+			err = errors.ErrInvalidMessage
+			return
+		}
+
+		channels = append(channels, string(data[offset:offset+n]))
+		offset += n
+	}
+
+	return
+}
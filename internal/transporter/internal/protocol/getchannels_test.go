@@ -0,0 +1,34 @@
+package protocol_test
+
+import (
+	"testing"
+
+	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
+	"github.com/dobyte/due/v2/internal/transporter/internal/protocol"
+	"github.com/dobyte/due/v2/session"
+)
+
+func TestDecodeGetChannelsReq(t *testing.T) {
+	buffer := protocol.EncodeGetChannelsReq(1, session.User, 3)
+
+	seq, kind, target, err := protocol.DecodeGetChannelsReq(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("seq: %v", seq)
+	t.Logf("kind: %v", kind)
+	t.Logf("target: %v", target)
+}
+
+func TestDecodeGetChannelsRes(t *testing.T) {
+	buffer := protocol.EncodeGetChannelsRes(1, codes.OK, "world.#", "world.zone1.chat")
+
+	code, channels, err := protocol.DecodeGetChannelsRes(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("code: %v", code)
+	t.Logf("channels: %v", channels)
+}
@@ -0,0 +1,102 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/dobyte/due/v2/core/buffer"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
+	"github.com/dobyte/due/v2/internal/transporter/internal/route"
+)
+
+const (
+	statChannelReqBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes
+	statChannelResBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + defaultCodeBytes + b64
+)
+
+// EncodeStatChannelReq 编码统计频道订阅者数量请求
+// 协议：size + header + route + seq + channel
+func EncodeStatChannelReq(seq uint64, channel string) buffer.Buffer {
+	size := statChannelReqBytes + len(channel)
+	buf := buffer.NewNocopyBuffer()
+	writer := buf.Malloc(size)
+	writer.WriteUint32s(binary.BigEndian, uint32(size-defaultSizeBytes))
+	writer.WriteUint8s(dataBit)
+	writer.WriteUint8s(route.StatChannel)
+	writer.WriteUint64s(binary.BigEndian, seq)
+	writer.WriteString(channel)
+
+	return buf
+}
+
+// DecodeStatChannelReq 解码统计频道订阅者数量请求
+// 协议：size + header + route + seq + channel
+func DecodeStatChannelReq(data []byte) (seq uint64, channel string, err error) {
+	if len(data) < statChannelReqBytes {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	reader := buffer.NewReader(data)
+
+	if _, err = reader.Seek(defaultSizeBytes+defaultHeaderBytes+defaultRouteBytes, io.SeekStart); err != nil {
+		return
+	}
+
+	if seq, err = reader.ReadUint64(binary.BigEndian); err != nil {
+		return
+	}
+
+	channel = string(data[statChannelReqBytes:])
+
+	return
+}
+
+// EncodeStatChannelRes 编码统计频道订阅者数量响应
+// 协议：size + header + route + seq + code + [total]
+func EncodeStatChannelRes(seq uint64, code uint16, total ...uint64) buffer.Buffer {
+	size := statChannelResBytes - defaultSizeBytes
+	if code != codes.OK || len(total) == 0 || total[0] == 0 {
+		size -= b64
+	}
+
+	buf := buffer.NewNocopyBuffer()
+	writer := buf.Malloc(size + defaultSizeBytes)
+	writer.WriteUint32s(binary.BigEndian, uint32(size))
+	writer.WriteUint8s(dataBit)
+	writer.WriteUint8s(route.StatChannel)
+	writer.WriteUint64s(binary.BigEndian, seq)
+	writer.WriteUint16s(binary.BigEndian, code)
+
+	if code == codes.OK && len(total) > 0 && total[0] != 0 {
+		writer.WriteUint64s(binary.BigEndian, total[0])
+	}
+
+	return buf
+}
+
+// DecodeStatChannelRes 解码统计频道订阅者数量响应
+// 协议：size + header + route + seq + code + [total]
+func DecodeStatChannelRes(data []byte) (code uint16, total uint64, err error) {
+	if len(data) != statChannelResBytes && len(data) != statChannelResBytes-b64 {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	reader := buffer.NewReader(data)
+
+	if _, err = reader.Seek(defaultSizeBytes+defaultHeaderBytes+defaultRouteBytes+defaultSeqBytes, io.SeekStart); err != nil {
+		return
+	}
+
+	if code, err = reader.ReadUint16(binary.BigEndian); err != nil {
+		return
+	}
+
+	if code == codes.OK && len(data) == statChannelResBytes {
+		total, err = reader.ReadUint64(binary.BigEndian)
+	}
+
+	return
+}
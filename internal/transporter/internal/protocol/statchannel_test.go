@@ -0,0 +1,32 @@
+package protocol_test
+
+import (
+	"testing"
+
+	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
+	"github.com/dobyte/due/v2/internal/transporter/internal/protocol"
+)
+
+func TestDecodeStatChannelReq(t *testing.T) {
+	buffer := protocol.EncodeStatChannelReq(1, "world.zone1.chat")
+
+	seq, channel, err := protocol.DecodeStatChannelReq(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("seq: %v", seq)
+	t.Logf("channel: %v", channel)
+}
+
+func TestDecodeStatChannelRes(t *testing.T) {
+	buffer := protocol.EncodeStatChannelRes(1, codes.OK, 20)
+
+	code, total, err := protocol.DecodeStatChannelRes(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("code: %v", code)
+	t.Logf("total: %v", total)
+}
@@ -0,0 +1,116 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/dobyte/due/v2/core/buffer"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/internal/transporter/internal/route"
+)
+
+const (
+	tellReqBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + b64 + b64 + b8 + b8
+	tellResBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + defaultCodeBytes
+)
+
+// EncodeTellReq 编码投递Actor消息请求
+// 协议：size + header + route + seq + cid + uid + target len + target + source len + source + <message packet>
+func EncodeTellReq(seq uint64, cid int64, uid int64, target, source string, message []byte) buffer.Buffer {
+	targetBytes := len([]byte(target))
+	sourceBytes := len([]byte(source))
+	size := tellReqBytes + targetBytes + sourceBytes
+	buf := buffer.NewNocopyBuffer()
+	writer := buf.Malloc(size)
+	writer.WriteUint32s(binary.BigEndian, uint32(size-defaultSizeBytes+len(message)))
+	writer.WriteUint8s(dataBit)
+	writer.WriteUint8s(route.Tell)
+	writer.WriteUint64s(binary.BigEndian, seq)
+	writer.WriteInt64s(binary.BigEndian, cid, uid)
+	writer.WriteUint8s(uint8(targetBytes))
+	writer.WriteString(target)
+	writer.WriteUint8s(uint8(sourceBytes))
+	writer.WriteString(source)
+	buf.Mount(message)
+
+	return buf
+}
+
+// DecodeTellReq 解码投递Actor消息请求
+// 协议：size + header + route + seq + cid + uid + target len + target + source len + source + <message packet>
+func DecodeTellReq(data []byte) (seq uint64, cid int64, uid int64, target, source string, message []byte, err error) {
+	reader := buffer.NewReader(data)
+
+	if _, err = reader.Seek(defaultSizeBytes+defaultHeaderBytes+defaultRouteBytes, io.SeekStart); err != nil {
+		return
+	}
+
+	if seq, err = reader.ReadUint64(binary.BigEndian); err != nil {
+		return
+	}
+
+	if cid, err = reader.ReadInt64(binary.BigEndian); err != nil {
+		return
+	}
+
+	if uid, err = reader.ReadInt64(binary.BigEndian); err != nil {
+		return
+	}
+
+	var targetBytes, sourceBytes uint8
+
+	if targetBytes, err = reader.ReadUint8(); err != nil {
+		return
+	}
+
+	if target, err = reader.ReadString(int(targetBytes)); err != nil {
+		return
+	}
+
+	if sourceBytes, err = reader.ReadUint8(); err != nil {
+		return
+	}
+
+	if source, err = reader.ReadString(int(sourceBytes)); err != nil {
+		return
+	}
+
+	message = data[tellReqBytes+int(targetBytes)+int(sourceBytes):]
+
+	return
+}
+
+// EncodeTellRes 编码投递Actor消息响应
+// 协议：size + header + route + seq + code
+func EncodeTellRes(seq uint64, code uint16) buffer.Buffer {
+	buf := buffer.NewNocopyBuffer()
+	writer := buf.Malloc(tellResBytes)
+	writer.WriteUint32s(binary.BigEndian, uint32(tellResBytes-defaultSizeBytes))
+	writer.WriteUint8s(dataBit)
+	writer.WriteUint8s(route.Tell)
+	writer.WriteUint64s(binary.BigEndian, seq)
+	writer.WriteUint16s(binary.BigEndian, code)
+
+	return buf
+}
+
+// DecodeTellRes 解码投递Actor消息响应
+// 协议：size + header + route + seq + code
+func DecodeTellRes(data []byte) (code uint16, err error) {
+	if len(data) != tellResBytes {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	reader := buffer.NewReader(data)
+
+	if _, err = reader.Seek(-defaultCodeBytes, io.SeekEnd); err != nil {
+		return
+	}
+
+	if code, err = reader.ReadUint16(binary.BigEndian); err != nil {
+		return
+	}
+
+	return
+}
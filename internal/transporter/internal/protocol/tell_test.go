@@ -0,0 +1,46 @@
+package protocol_test
+
+import (
+	"testing"
+
+	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
+	"github.com/dobyte/due/v2/internal/transporter/internal/protocol"
+)
+
+func TestEncodeTellReq(t *testing.T) {
+	buffer := protocol.EncodeTellReq(1, 2, 3, "room/1", "guild/2", []byte("hello world"))
+
+	t.Log(buffer.Bytes())
+}
+
+func TestDecodeTellReq(t *testing.T) {
+	buffer := protocol.EncodeTellReq(1, 2, 3, "room/1", "guild/2", []byte("hello world"))
+
+	seq, cid, uid, target, source, message, err := protocol.DecodeTellReq(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seq != 1 || cid != 2 || uid != 3 || target != "room/1" || source != "guild/2" || string(message) != "hello world" {
+		t.Fatalf("decode mismatch: %v %v %v %v %v %v", seq, cid, uid, target, source, string(message))
+	}
+}
+
+func TestEncodeTellRes(t *testing.T) {
+	buffer := protocol.EncodeTellRes(1, codes.OK)
+
+	t.Log(buffer.Bytes())
+}
+
+func TestDecodeTellRes(t *testing.T) {
+	buffer := protocol.EncodeTellRes(1, codes.NotFoundActor)
+
+	code, err := protocol.DecodeTellRes(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != codes.NotFoundActor {
+		t.Fatalf("code mismatch: %v", code)
+	}
+}
@@ -6,31 +6,42 @@ import (
 	"github.com/dobyte/due/v2/core/buffer"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/internal/transporter/internal/route"
+	"github.com/dobyte/due/v2/trace"
 	"io"
 )
 
 const (
-	triggerReqBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + b8 + b64 + b64
+	triggerReqBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + b8 + b64
 	triggerResBytes = defaultSizeBytes + defaultHeaderBytes + defaultRouteBytes + defaultSeqBytes + defaultCodeBytes
 )
 
 // EncodeTriggerReq 编码触发事件请求
-// 协议：size + header + route + seq + event + cid + [uid]
-func EncodeTriggerReq(seq uint64, event cluster.Event, cid int64, uid ...int64) buffer.Buffer {
-	size := triggerReqBytes - defaultSizeBytes
-	if len(uid) == 0 || uid[0] == 0 {
-		size -= b64
+// 协议：size + header + route + seq + event + cid + [trace] + [uid]
+// trace为链路上下文，仅在链路上下文有效时写入并在header中置traceBit
+func EncodeTriggerReq(seq uint64, event cluster.Event, cid int64, sc trace.SpanContext, uid ...int64) buffer.Buffer {
+	header, size := dataBit, triggerReqBytes
+	if sc.IsValid() {
+		header |= traceBit
+		size += trace.SpanContextSize
+	}
+
+	if len(uid) > 0 && uid[0] != 0 {
+		size += b64
 	}
 
 	buf := buffer.NewNocopyBuffer()
-	writer := buf.Malloc(triggerReqBytes)
-	writer.WriteUint32s(binary.BigEndian, uint32(size))
-	writer.WriteUint8s(dataBit)
+	writer := buf.Malloc(size)
+	writer.WriteUint32s(binary.BigEndian, uint32(size-defaultSizeBytes))
+	writer.WriteUint8s(header)
 	writer.WriteUint8s(route.Trigger)
 	writer.WriteUint64s(binary.BigEndian, seq)
 	writer.WriteUint8s(uint8(event))
 	writer.WriteInt64s(binary.BigEndian, cid)
 
+	if sc.IsValid() {
+		writer.WriteBytes(sc.Bytes()...)
+	}
+
 	if len(uid) > 0 && uid[0] != 0 {
 		writer.WriteInt64s(binary.BigEndian, uid[0])
 	}
@@ -39,9 +50,25 @@ func EncodeTriggerReq(seq uint64, event cluster.Event, cid int64, uid ...int64)
 }
 
 // DecodeTriggerReq 解码触发事件请求
-// 协议：size + header + route + seq + event + cid + [uid]
-func DecodeTriggerReq(data []byte) (seq uint64, event cluster.Event, cid int64, uid int64, err error) {
-	if len(data) != triggerReqBytes && len(data) != triggerReqBytes-b64 {
+// 协议：size + header + route + seq + event + cid + [trace] + [uid]
+// header中存在未知的标识位时视为无效消息
+func DecodeTriggerReq(data []byte) (seq uint64, event cluster.Event, cid int64, uid int64, sc trace.SpanContext, err error) {
+	if len(data) < triggerReqBytes {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	header, size := data[defaultSizeBytes], triggerReqBytes
+	if header&^traceBit != dataBit {
+		err = errors.ErrInvalidMessage
+		return
+	}
+
+	if header&traceBit != 0 {
+		size += trace.SpanContextSize
+	}
+
+	if len(data) != size && len(data) != size+b64 {
 		err = errors.ErrInvalidMessage
 		return
 	}
@@ -67,7 +94,16 @@ func DecodeTriggerReq(data []byte) (seq uint64, event cluster.Event, cid int64,
 		return
 	}
 
-	if len(data) == triggerReqBytes {
+	if header&traceBit != 0 {
+		var b []byte
+		if b, err = reader.ReadBytes(trace.SpanContextSize); err != nil {
+			return
+		}
+
+		sc, _ = trace.SpanContextFromBytes(b)
+	}
+
+	if len(data) == size+b64 {
 		uid, err = reader.ReadInt64(binary.BigEndian)
 	}
 
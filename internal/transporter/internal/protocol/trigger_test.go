@@ -1,30 +1,83 @@
 package protocol_test
 
 import (
+	"bytes"
+	"encoding/binary"
 	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
 	"github.com/dobyte/due/v2/internal/transporter/internal/protocol"
+	"github.com/dobyte/due/v2/internal/transporter/internal/route"
+	"github.com/dobyte/due/v2/trace"
 	"testing"
 )
 
 func TestEncodeTriggerReq(t *testing.T) {
-	buffer := protocol.EncodeTriggerReq(1, cluster.Disconnect, 1)
+	buffer := protocol.EncodeTriggerReq(1, cluster.Disconnect, 1, trace.SpanContext{})
 
 	t.Log(buffer.Bytes())
 }
 
 func TestDecodeTriggerReq(t *testing.T) {
-	buffer := protocol.EncodeTriggerReq(1, cluster.Disconnect, 1, 2)
+	parent, _ := trace.ParseSpanContext("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	buffer := protocol.EncodeTriggerReq(1, cluster.Disconnect, 1, parent, 2)
 
-	seq, evt, cid, uid, err := protocol.DecodeTriggerReq(buffer.Bytes())
+	seq, evt, cid, uid, sc, err := protocol.DecodeTriggerReq(buffer.Bytes())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	t.Logf("seq: %v", seq)
-	t.Logf("evt: %v", evt)
-	t.Logf("cid: %v", cid)
-	t.Logf("uid: %v", uid)
+	if seq != 1 || evt != cluster.Disconnect || cid != 1 || uid != 2 {
+		t.Fatalf("unexpected request, seq: %d evt: %v cid: %d uid: %d", seq, evt, cid, uid)
+	}
+
+	if sc != parent {
+		t.Fatalf("trace mismatch, want: %s got: %s", parent, sc)
+	}
+}
+
+func TestDecodeTriggerReq_NoUID(t *testing.T) {
+	buffer := protocol.EncodeTriggerReq(1, cluster.Connect, 3, trace.SpanContext{})
+
+	seq, evt, cid, uid, sc, err := protocol.DecodeTriggerReq(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seq != 1 || evt != cluster.Connect || cid != 3 || uid != 0 || sc.IsValid() {
+		t.Fatalf("unexpected request, seq: %d evt: %v cid: %d uid: %d trace: %s", seq, evt, cid, uid, sc)
+	}
+}
+
+func TestDecodeTriggerReq_Legacy(t *testing.T) {
+	// 旧版本协议：size + header + route + seq + event + cid + [uid]
+	data := binary.BigEndian.AppendUint32(nil, 1+1+8+1+8+8)
+	data = append(data, 0, route.Trigger)
+	data = binary.BigEndian.AppendUint64(data, 1)
+	data = append(data, uint8(cluster.Disconnect))
+	data = binary.BigEndian.AppendUint64(data, 3)
+	data = binary.BigEndian.AppendUint64(data, 4)
+
+	seq, evt, cid, uid, sc, err := protocol.DecodeTriggerReq(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seq != 1 || evt != cluster.Disconnect || cid != 3 || uid != 4 || sc.IsValid() {
+		t.Fatalf("unexpected request, seq: %d evt: %v cid: %d uid: %d trace: %s", seq, evt, cid, uid, sc)
+	}
+
+	// 未携带链路上下文时编码结果与旧版本协议一致
+	if buffer := protocol.EncodeTriggerReq(1, cluster.Disconnect, 3, trace.SpanContext{}, 4); !bytes.Equal(buffer.Bytes(), data) {
+		t.Fatalf("encoded request mismatch legacy layout, want: %v got: %v", data, buffer.Bytes())
+	}
+
+	unknown := append([]byte(nil), data...)
+	unknown[4] |= 1 << 6
+
+	if _, _, _, _, _, err = protocol.DecodeTriggerReq(unknown); !errors.Is(err, errors.ErrInvalidMessage) {
+		t.Fatalf("unknown header flag should be rejected, err: %v", err)
+	}
 }
 
 func TestEncodeTriggerRes(t *testing.T) {
@@ -34,12 +87,14 @@ func TestEncodeTriggerRes(t *testing.T) {
 }
 
 func TestDecodeTriggerRes(t *testing.T) {
-	buffer := protocol.EncodeTriggerRes(1, codes.OK)
+	buffer := protocol.EncodeTriggerRes(1, codes.NotFoundSession)
 
 	code, err := protocol.DecodeTriggerRes(buffer.Bytes())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	t.Logf("code: %v", code)
+	if code != codes.NotFoundSession {
+		t.Fatalf("code mismatch, want: %d got: %d", codes.NotFoundSession, code)
+	}
 }
@@ -18,4 +18,7 @@ const (
 	Deliver                      // 投递消息
 	GetState                     // 获取状态
 	SetState                     // 设置状态
+	Tell                         // 投递Actor消息
+	StatChannel                  // 统计频道订阅者数量
+	GetChannels                  // 获取订阅的频道
 )
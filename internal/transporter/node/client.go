@@ -3,11 +3,14 @@ package node
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/internal/transporter/internal/client"
 	"github.com/dobyte/due/v2/internal/transporter/internal/codes"
 	"github.com/dobyte/due/v2/internal/transporter/internal/protocol"
+	"github.com/dobyte/due/v2/trace"
 )
 
 type Client struct {
@@ -22,13 +25,42 @@ func NewClient(cli *client.Client) *Client {
 }
 
 // Trigger 触发事件
+// ctx中存在链路上下文时，链路上下文将随事件一并投递给节点
 func (c *Client) Trigger(ctx context.Context, event cluster.Event, cid, uid int64) error {
-	return c.cli.Send(ctx, protocol.EncodeTriggerReq(0, event, cid, uid))
+	return c.cli.Send(ctx, protocol.EncodeTriggerReq(0, event, cid, trace.SpanContextFromContext(ctx), uid))
 }
 
 // Deliver 投递消息
+// ctx设置了截止时间或存在链路上下文时，截止时间及链路上下文将随消息一并投递给节点
 func (c *Client) Deliver(ctx context.Context, cid, uid int64, message []byte) error {
-	return c.cli.Send(ctx, protocol.EncodeDeliverReq(0, cid, uid, message), cid)
+	var timeout int64
+
+	if d, ok := ctx.Deadline(); ok {
+		if timeout = int64(time.Until(d)); timeout <= 0 {
+			return errors.ErrDeadlineExceeded
+		}
+	}
+
+	return c.cli.Send(ctx, protocol.EncodeDeliverReq(0, cid, uid, timeout, trace.SpanContextFromContext(ctx), message), cid)
+}
+
+// Tell 投递Actor消息
+func (c *Client) Tell(ctx context.Context, cid, uid int64, target, source string, message []byte) error {
+	seq := c.doGenSequence()
+
+	buf := protocol.EncodeTellReq(seq, cid, uid, target, source, message)
+
+	res, err := c.cli.Call(ctx, seq, buf)
+	if err != nil {
+		return err
+	}
+
+	code, err := protocol.DecodeTellRes(res)
+	if err != nil {
+		return err
+	}
+
+	return codes.CodeToError(code)
 }
 
 // GetState 获取状态
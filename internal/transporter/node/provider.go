@@ -8,8 +8,10 @@ import (
 type Provider interface {
 	// Trigger 触发事件
 	Trigger(ctx context.Context, gid string, cid, uid int64, event cluster.Event) error
-	// Deliver 投递消息
+	// Deliver 投递消息，ctx携带了请求的截止时间
 	Deliver(ctx context.Context, gid, nid string, cid, uid int64, message []byte) error
+	// Tell 投递Actor消息
+	Tell(ctx context.Context, nid string, cid, uid int64, target, source string, message []byte) error
 	// GetState 获取状态
 	GetState() (cluster.State, error)
 	// SetState 设置状态
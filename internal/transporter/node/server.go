@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"time"
 
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/errors"
@@ -9,6 +10,7 @@ import (
 	"github.com/dobyte/due/v2/internal/transporter/internal/protocol"
 	"github.com/dobyte/due/v2/internal/transporter/internal/route"
 	"github.com/dobyte/due/v2/internal/transporter/internal/server"
+	"github.com/dobyte/due/v2/trace"
 )
 
 type Server struct {
@@ -35,11 +37,12 @@ func (s *Server) init() {
 	s.RegisterHandler(route.Deliver, s.deliver)
 	s.RegisterHandler(route.GetState, s.getState)
 	s.RegisterHandler(route.SetState, s.setState)
+	s.RegisterHandler(route.Tell, s.tell)
 }
 
 // 触发事件
 func (s *Server) trigger(conn *server.Conn, data []byte) error {
-	seq, event, cid, uid, err := protocol.DecodeTriggerReq(data)
+	seq, event, cid, uid, sc, err := protocol.DecodeTriggerReq(data)
 	if err != nil {
 		return err
 	}
@@ -48,7 +51,7 @@ func (s *Server) trigger(conn *server.Conn, data []byte) error {
 		return errors.ErrIllegalRequest
 	}
 
-	if err = s.provider.Trigger(context.Background(), conn.InsID, cid, uid, event); seq == 0 {
+	if err = s.provider.Trigger(trace.ContextWithSpanContext(context.Background(), sc), conn.InsID, cid, uid, event); seq == 0 {
 		if errors.Is(err, errors.ErrNotFoundSession) {
 			return nil
 		} else {
@@ -61,7 +64,7 @@ func (s *Server) trigger(conn *server.Conn, data []byte) error {
 
 // 投递消息
 func (s *Server) deliver(conn *server.Conn, data []byte) error {
-	seq, cid, uid, message, err := protocol.DecodeDeliverReq(data)
+	seq, cid, uid, timeout, sc, message, err := protocol.DecodeDeliverReq(data)
 	if err != nil {
 		return err
 	}
@@ -80,7 +83,16 @@ func (s *Server) deliver(conn *server.Conn, data []byte) error {
 		return errors.ErrIllegalRequest
 	}
 
-	if err = s.provider.Deliver(context.Background(), gid, nid, cid, uid, message); seq == 0 {
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	// 以本地时钟重建截止时间
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout))
+		defer cancel()
+	}
+
+	if err = s.provider.Deliver(ctx, gid, nid, cid, uid, message); seq == 0 {
 		return err
 	} else {
 		return conn.Send(protocol.EncodeDeliverRes(seq, codes.ErrorToCode(err)))
@@ -110,3 +122,21 @@ func (s *Server) setState(conn *server.Conn, data []byte) error {
 
 	return conn.Send(protocol.EncodeSetStateRes(seq, codes.ErrorToCode(err)))
 }
+
+// 投递Actor消息
+func (s *Server) tell(conn *server.Conn, data []byte) error {
+	seq, cid, uid, target, source, message, err := protocol.DecodeTellReq(data)
+	if err != nil {
+		return err
+	}
+
+	if conn.InsKind != cluster.Node {
+		return errors.ErrIllegalRequest
+	}
+
+	if err = s.provider.Tell(context.Background(), conn.InsID, cid, uid, target, source, message); seq == 0 {
+		return err
+	} else {
+		return conn.Send(protocol.EncodeTellRes(seq, codes.ErrorToCode(err)))
+	}
+}
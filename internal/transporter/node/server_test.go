@@ -40,6 +40,12 @@ func (p *provider) Deliver(ctx context.Context, gid, nid string, cid, uid int64,
 	return nil
 }
 
+// Tell 投递Actor消息
+func (p *provider) Tell(ctx context.Context, nid string, cid, uid int64, target, source string, message []byte) error {
+	log.Infof("nid: %s, cid: %d, uid: %d target: %s source: %s message: %s", nid, cid, uid, target, source, string(message))
+	return nil
+}
+
 // GetState 获取状态
 func (p *provider) GetState() (cluster.State, error) {
 	return cluster.Work, nil
@@ -30,6 +30,17 @@ type Locator interface {
 	LocateNode(ctx context.Context, uid int64, name string) (string, error)
 }
 
+// ActorLocator Actor定位器
+// 定位器实现此接口后，节点服务器可通过Actor的PID定位其所在节点，进而实现跨节点的Actor消息投递
+type ActorLocator interface {
+	// BindActor 绑定Actor所在节点
+	BindActor(ctx context.Context, kind, id, nid string) error
+	// UnbindActor 解绑Actor所在节点
+	UnbindActor(ctx context.Context, kind, id, nid string) error
+	// LocateActor 定位Actor所在节点
+	LocateActor(ctx context.Context, kind, id string) (string, error)
+}
+
 type Watcher interface {
 	// Next 返回用户位置列表
 	Next() ([]*Event, error)
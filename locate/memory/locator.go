@@ -0,0 +1,211 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/locate"
+)
+
+const name = "memory"
+
+var _ locate.Locator = &Locator{}
+
+var _ locate.ActorLocator = &Locator{}
+
+// Locator 基于内存的定位器
+// 仅在当前进程内生效，适用于在同一进程中运行整个集群的本地开发及集成测试场景
+// 同一集群内的所有组件需共用同一个定位器实例
+type Locator struct {
+	rw       sync.RWMutex
+	gates    map[int64]string             // 用户ID -> 网关ID
+	nodes    map[int64]map[string]string  // 用户ID -> 节点名称 -> 节点ID
+	actors   map[string]map[string]string // Actor类型 -> Actor ID -> 节点ID
+	watchers map[*watcher]struct{}
+}
+
+func NewLocator() *Locator {
+	return &Locator{
+		gates:    make(map[int64]string),
+		nodes:    make(map[int64]map[string]string),
+		actors:   make(map[string]map[string]string),
+		watchers: make(map[*watcher]struct{}),
+	}
+}
+
+// Name 获取定位器组件名
+func (l *Locator) Name() string {
+	return name
+}
+
+// LocateGate 定位用户所在网关
+func (l *Locator) LocateGate(ctx context.Context, uid int64) (string, error) {
+	l.rw.RLock()
+	defer l.rw.RUnlock()
+
+	return l.gates[uid], nil
+}
+
+// LocateNode 定位用户所在节点
+func (l *Locator) LocateNode(ctx context.Context, uid int64, name string) (string, error) {
+	l.rw.RLock()
+	defer l.rw.RUnlock()
+
+	return l.nodes[uid][name], nil
+}
+
+// BindGate 绑定网关
+func (l *Locator) BindGate(ctx context.Context, uid int64, gid string) error {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	l.gates[uid] = gid
+
+	l.broadcast(locate.BindGate, uid, gid, "")
+
+	return nil
+}
+
+// BindNode 绑定节点
+func (l *Locator) BindNode(ctx context.Context, uid int64, name, nid string) error {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	nodes, ok := l.nodes[uid]
+	if !ok {
+		nodes = make(map[string]string)
+		l.nodes[uid] = nodes
+	}
+
+	nodes[name] = nid
+
+	l.broadcast(locate.BindNode, uid, nid, name)
+
+	return nil
+}
+
+// UnbindGate 解绑网关
+// 仅在用户当前绑定的网关与gid一致时解绑
+func (l *Locator) UnbindGate(ctx context.Context, uid int64, gid string) error {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	if l.gates[uid] != gid {
+		return nil
+	}
+
+	delete(l.gates, uid)
+
+	l.broadcast(locate.UnbindGate, uid, gid, "")
+
+	return nil
+}
+
+// UnbindNode 解绑节点
+// 仅在用户当前绑定的节点与nid一致时解绑
+func (l *Locator) UnbindNode(ctx context.Context, uid int64, name, nid string) error {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	nodes, ok := l.nodes[uid]
+	if !ok || nodes[name] != nid {
+		return nil
+	}
+
+	delete(nodes, name)
+
+	if len(nodes) == 0 {
+		delete(l.nodes, uid)
+	}
+
+	l.broadcast(locate.UnbindNode, uid, nid, name)
+
+	return nil
+}
+
+// LocateActor 定位Actor所在节点
+func (l *Locator) LocateActor(ctx context.Context, kind, id string) (string, error) {
+	l.rw.RLock()
+	defer l.rw.RUnlock()
+
+	return l.actors[kind][id], nil
+}
+
+// BindActor 绑定Actor所在节点
+func (l *Locator) BindActor(ctx context.Context, kind, id, nid string) error {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	actors, ok := l.actors[kind]
+	if !ok {
+		actors = make(map[string]string)
+		l.actors[kind] = actors
+	}
+
+	actors[id] = nid
+
+	return nil
+}
+
+// UnbindActor 解绑Actor所在节点
+// 仅在Actor当前绑定的节点与nid一致时解绑
+func (l *Locator) UnbindActor(ctx context.Context, kind, id, nid string) error {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	actors, ok := l.actors[kind]
+	if !ok || actors[id] != nid {
+		return nil
+	}
+
+	delete(actors, id)
+
+	if len(actors) == 0 {
+		delete(l.actors, kind)
+	}
+
+	return nil
+}
+
+// Watch 监听用户定位变化
+func (l *Locator) Watch(ctx context.Context, kinds ...string) (locate.Watcher, error) {
+	w := newWatcher(l, kinds)
+
+	l.rw.Lock()
+	l.watchers[w] = struct{}{}
+	l.rw.Unlock()
+
+	return w, nil
+}
+
+// 广播事件，调用方需持有写锁
+func (l *Locator) broadcast(typ locate.EventType, uid int64, insID, insName string) {
+	if len(l.watchers) == 0 {
+		return
+	}
+
+	evt := &locate.Event{UID: uid, Type: typ, InsID: insID, InsName: insName}
+
+	switch typ {
+	case locate.BindGate, locate.UnbindGate:
+		evt.InsKind = cluster.Gate.String()
+	case locate.BindNode, locate.UnbindNode:
+		evt.InsKind = cluster.Node.String()
+	}
+
+	for w := range l.watchers {
+		if len(w.kinds) == 0 || slices.Contains(w.kinds, evt.InsKind) {
+			w.notify(evt)
+		}
+	}
+}
+
+// 移除监听器
+func (l *Locator) recycle(w *watcher) {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	delete(l.watchers, w)
+}
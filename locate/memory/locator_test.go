@@ -0,0 +1,79 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/locate"
+	"github.com/dobyte/due/v2/locate/memory"
+)
+
+func TestLocator_Watch(t *testing.T) {
+	ctx := context.Background()
+	locator := memory.NewLocator()
+
+	watcher, err := locator.Watch(ctx, cluster.Gate.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	if err = locator.BindGate(ctx, 1, "gate-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = locator.BindNode(ctx, 1, "node", "node-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = locator.UnbindGate(ctx, 1, "gate-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = locator.UnbindGate(ctx, 1, "gate-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := watcher.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 || events[0].Type != locate.BindGate || events[1].Type != locate.UnbindGate {
+		t.Fatalf("unexpected events: %v", events)
+	}
+
+	if gid, _ := locator.LocateGate(ctx, 1); gid != "" {
+		t.Fatalf("gate should be unbound, got: %s", gid)
+	}
+
+	if nid, _ := locator.LocateNode(ctx, 1, "node"); nid != "node-1" {
+		t.Fatalf("node mismatch, want: node-1 got: %s", nid)
+	}
+}
+
+func TestLocator_Actor(t *testing.T) {
+	ctx := context.Background()
+	locator := memory.NewLocator()
+
+	if err := locator.BindActor(ctx, "room", "1", "node-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := locator.UnbindActor(ctx, "room", "1", "node-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if nid, _ := locator.LocateActor(ctx, "room", "1"); nid != "node-1" {
+		t.Fatalf("actor node mismatch, want: node-1 got: %s", nid)
+	}
+
+	if err := locator.UnbindActor(ctx, "room", "1", "node-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if nid, _ := locator.LocateActor(ctx, "room", "1"); nid != "" {
+		t.Fatalf("actor should be unbound, got: %s", nid)
+	}
+}
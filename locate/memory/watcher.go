@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dobyte/due/v2/locate"
+)
+
+type watcher struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	locator *Locator
+	kinds   []string
+	events  []*locate.Event
+	chEvent chan struct{}
+}
+
+func newWatcher(l *Locator, kinds []string) *watcher {
+	w := &watcher{}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.locator = l
+	w.kinds = kinds
+	w.chEvent = make(chan struct{}, 1)
+
+	return w
+}
+
+// 通知定位事件，未被读取的事件将累积到下一次读取时一并返回
+func (w *watcher) notify(evt *locate.Event) {
+	w.mu.Lock()
+	w.events = append(w.events, evt)
+	w.mu.Unlock()
+
+	select {
+	case w.chEvent <- struct{}{}:
+	default:
+	}
+}
+
+// Next 返回变动事件列表
+func (w *watcher) Next() ([]*locate.Event, error) {
+	for {
+		select {
+		case <-w.ctx.Done():
+			return nil, w.ctx.Err()
+		case <-w.chEvent:
+			w.mu.Lock()
+			events := w.events
+			w.events = nil
+			w.mu.Unlock()
+
+			if len(events) > 0 {
+				return events, nil
+			}
+		}
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	w.locator.recycle(w)
+
+	return nil
+}
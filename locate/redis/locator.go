@@ -20,6 +20,7 @@ import (
 const (
 	userGateKey     = "%s:locate:user:%d:gate"     // string
 	userNodeKey     = "%s:locate:user:%d:node"     // hash
+	actorNodeKey    = "%s:locate:actor:%s:node"    // hash
 	clusterEventKey = "%s:locate:cluster:%s:event" // channel
 )
 
@@ -27,6 +28,8 @@ const name = "redis"
 
 var _ locate.Locator = &Locator{}
 
+var _ locate.ActorLocator = &Locator{}
+
 type Locator struct {
 	err              error
 	opts             *options
@@ -210,6 +213,53 @@ func (l *Locator) UnbindNode(ctx context.Context, uid int64, name, nid string) e
 	return nil
 }
 
+// LocateActor 定位Actor所在节点
+func (l *Locator) LocateActor(ctx context.Context, kind, id string) (string, error) {
+	if l.err != nil {
+		return "", l.err
+	}
+
+	key := fmt.Sprintf(actorNodeKey, l.opts.prefix, kind)
+
+	val, err, _ := l.sfg.Do(key+id, func() (any, error) {
+		val, err := l.opts.client.HGet(ctx, key, id).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return "", err
+		}
+
+		return val, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return val.(string), nil
+}
+
+// BindActor 绑定Actor所在节点
+func (l *Locator) BindActor(ctx context.Context, kind, id, nid string) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	key := fmt.Sprintf(actorNodeKey, l.opts.prefix, kind)
+
+	return l.opts.client.HSet(ctx, key, id, nid).Err()
+}
+
+// UnbindActor 解绑Actor所在节点
+func (l *Locator) UnbindActor(ctx context.Context, kind, id, nid string) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	key := fmt.Sprintf(actorNodeKey, l.opts.prefix, kind)
+
+	_, err := l.unbindNodeScript.Run(ctx, l.opts.client, []string{key}, id, nid).StringSlice()
+
+	return err
+}
+
 // 广播事件
 func (l *Locator) broadcast(ctx context.Context, typ locate.EventType, uid int64, insID string, insName ...string) error {
 	evt := &locate.Event{UID: uid, Type: typ, InsID: insID}
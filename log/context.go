@@ -0,0 +1,21 @@
+package log
+
+import "context"
+
+type loggerKey struct{}
+
+// NewContext 将日志记录器写入上下文
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext 获取上下文中的日志记录器，不存在时返回全局日志记录器
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(Logger); ok {
+			return logger
+		}
+	}
+
+	return globalLogger
+}
@@ -16,6 +16,7 @@ type (
 	Level  = internal.Level
 	Entity = internal.Entity
 	Syncer = internal.Syncer
+	Field  = internal.Field
 )
 
 const (
@@ -27,3 +28,8 @@ const (
 	LevelFatal = internal.LevelFatal
 	LevelPanic = internal.LevelPanic
 )
+
+// Any 创建日志字段
+func Any(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
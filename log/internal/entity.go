@@ -13,4 +13,5 @@ type Entity struct {
 	Message  string
 	Caller   string
 	Frames   []runtime.Frame
+	Fields   []Field
 }
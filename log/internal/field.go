@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"unicode/utf8"
+)
+
+// Field 日志字段
+type Field struct {
+	Key   string
+	Value any
+}
+
+const hex = "0123456789abcdef"
+
+// 写入JSON格式的字段值，数值及布尔值原样输出，其余类型按字符串输出
+func writeJsonValue(b *buffer, val any) {
+	switch v := val.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool:
+		b.WriteString(String(v))
+	case nil:
+		b.WriteString("null")
+	case error:
+		writeJsonString(b, v.Error())
+	default:
+		writeJsonString(b, String(v))
+	}
+}
+
+// 写入转义后的JSON字符串
+func writeJsonString(b *buffer, s string) {
+	b.WriteByte('"')
+	writeJsonEscape(b, s)
+	b.WriteByte('"')
+}
+
+// 写入JSON转义后的字符串内容
+func writeJsonEscape(b *buffer, s string) {
+	start := 0
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+
+			b.WriteString(s[start:i])
+
+			switch c {
+			case '"', '\\':
+				b.WriteByte('\\')
+				b.WriteByte(c)
+			case '\n':
+				b.WriteString(`\n`)
+			case '\r':
+				b.WriteString(`\r`)
+			case '\t':
+				b.WriteString(`\t`)
+			default:
+				b.WriteString(`\u00`)
+				b.WriteByte(hex[c>>4])
+				b.WriteByte(hex[c&0xf])
+			}
+
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteString(s[start:i])
+			b.WriteString(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+
+		i += size
+	}
+
+	b.WriteString(s[start:])
+}
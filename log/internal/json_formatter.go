@@ -61,9 +61,15 @@ func (f *JsonFormatter) Format(entity *Entity) Buffer {
 	if entity.Message != "" {
 		b.WriteString(`,"`)
 		b.WriteString(fieldKeyMsg)
-		b.WriteString(`":"`)
-		b.WriteString(entity.Message)
-		b.WriteString(`"`)
+		b.WriteString(`":`)
+		writeJsonString(b, entity.Message)
+	}
+
+	for _, field := range entity.Fields {
+		b.WriteString(`,`)
+		writeJsonString(b, field.Key)
+		b.WriteString(`:`)
+		writeJsonValue(b, field.Value)
 	}
 
 	if len(entity.Frames) > 0 {
@@ -0,0 +1,46 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dobyte/due/v2/log/internal"
+)
+
+func TestJsonFormatter_Format(t *testing.T) {
+	formatter := internal.NewJsonFormatter()
+
+	buf := formatter.Format(&internal.Entity{
+		Time:    "2024/01/01 00:00:00.000000",
+		Level:   internal.LevelInfo,
+		Message: "say \"hello\"\n",
+		Fields: []internal.Field{
+			{Key: "uid", Value: int64(1)},
+			{Key: "route", Value: int32(2)},
+			{Key: "gid", Value: "gate-1"},
+			{Key: "err", Value: errors.New("failed")},
+		},
+	})
+	defer buf.Release()
+
+	data := make(map[string]any)
+
+	if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+		t.Fatalf("invalid json: %v, %s", err, buf.Bytes())
+	}
+
+	want := map[string]any{
+		"msg":   "say \"hello\"\n",
+		"uid":   float64(1),
+		"route": float64(2),
+		"gid":   "gate-1",
+		"err":   "failed",
+	}
+
+	for key, value := range want {
+		if data[key] != value {
+			t.Errorf("field %s mismatch, want: %v got: %v", key, value, data[key])
+		}
+	}
+}
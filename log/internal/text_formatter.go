@@ -44,6 +44,13 @@ func (f *TextFormatter) Format(entity *Entity) Buffer {
 		b.WriteString(entity.Message)
 	}
 
+	for _, field := range entity.Fields {
+		b.WriteRune(' ')
+		b.WriteString(field.Key)
+		b.WriteRune('=')
+		b.WriteString(String(field.Value))
+	}
+
 	if len(entity.Frames) > 0 {
 		b.WriteString("\nStack:")
 		for i, frame := range entity.Frames {
@@ -16,6 +16,11 @@ func SetLogger(logger Logger) {
 		globalLogger.Close()
 	}
 
+	// 全局日志记录器经由全局日志方法包装调用，需修正子日志记录器的调用栈跳过深度
+	if l, ok := logger.(*defaultLogger); ok {
+		logger = l.wrap()
+	}
+
 	globalLogger = logger
 }
 
@@ -122,6 +127,20 @@ func Panicf(format string, a ...any) {
 	}
 }
 
+// With 创建携带字段的子日志记录器，全局日志记录器未实现FieldLogger接口时忽略字段
+func With(fields ...Field) Logger {
+	return WithFields(globalLogger, fields...)
+}
+
+// WithFields 创建携带字段的子日志记录器，日志记录器未实现FieldLogger接口时忽略字段并返回原日志记录器
+func WithFields(logger Logger, fields ...Field) Logger {
+	if l, ok := logger.(FieldLogger); ok {
+		return l.With(fields...)
+	}
+
+	return logger
+}
+
 // Close 关闭日志
 func Close() {
 	if globalLogger != nil {
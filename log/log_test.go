@@ -1,6 +1,8 @@
 package log_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/dobyte/due/v2/log"
@@ -14,3 +16,75 @@ func TestLog(t *testing.T) {
 	logger.Warn("welcome to due-framework")
 	logger.Error("welcome to due-framework")
 }
+
+func TestWith(t *testing.T) {
+	logger := log.With(log.Any("uid", 1), log.Any("route", 2))
+
+	logger.Info("welcome to due-framework")
+	log.WithFields(logger, log.Any("seq", 3)).Warn("welcome to due-framework")
+
+	ctx := log.NewContext(context.Background(), logger)
+
+	if log.FromContext(ctx) != logger {
+		t.Fatal("logger from context mismatch")
+	}
+
+	if log.FromContext(context.Background()) != log.GetLogger() {
+		t.Fatal("logger from empty context should be the global logger")
+	}
+}
+
+type captureSyncer struct {
+	callers []string
+}
+
+func (s *captureSyncer) Name() string {
+	return "capture"
+}
+
+func (s *captureSyncer) Write(entity *log.Entity) error {
+	s.callers = append(s.callers, entity.Caller)
+	return nil
+}
+
+func (s *captureSyncer) Close() error {
+	return nil
+}
+
+func TestWith_Caller(t *testing.T) {
+	defer log.SetLogger(log.NewLogger())
+
+	syncer := &captureSyncer{}
+	log.SetLogger(log.NewLogger(log.WithTerminals(log.Terminal("capture")), log.WithSyncers(syncer)))
+
+	log.Info("global")
+	logger := log.With(log.Any("uid", 1))
+	logger.Info("child")
+	log.WithFields(logger, log.Any("seq", 2)).Info("grandchild")
+
+	// 子日志记录器被设置为全局日志记录器后经由全局日志方法调用
+	log.SetLogger(logger)
+	log.Info("wrapped child")
+
+	if len(syncer.callers) != 4 {
+		t.Fatalf("caller count mismatch, want: 4 got: %d", len(syncer.callers))
+	}
+
+	for _, caller := range syncer.callers {
+		if !strings.HasPrefix(caller, "log_test.go:") {
+			t.Fatalf("unexpected caller: %v", syncer.callers)
+		}
+	}
+}
+
+type customLogger struct {
+	log.Logger
+}
+
+func TestWithFields_CustomLogger(t *testing.T) {
+	logger := &customLogger{Logger: log.GetLogger()}
+
+	if log.WithFields(logger, log.Any("uid", 1)) != log.Logger(logger) {
+		t.Fatal("custom logger without field support should be returned as is")
+	}
+}
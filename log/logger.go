@@ -50,6 +50,14 @@ type Logger interface {
 	Close() error
 }
 
+// FieldLogger 支持携带字段的日志记录器
+// 自定义日志记录器未实现此接口时，With与WithFields将忽略字段并返回原日志记录器
+type FieldLogger interface {
+	Logger
+	// With 创建携带字段的子日志记录器，子日志记录器与父日志记录器共享输出终端
+	With(fields ...Field) FieldLogger
+}
+
 type terminal struct {
 	syncer Syncer
 	levels map[Level]bool
@@ -59,6 +67,9 @@ type defaultLogger struct {
 	opts      *options
 	pool      *sync.Pool
 	terminals []*terminal
+	fields    []Field
+	callSkip  int
+	wrapped   bool // 是否经由全局日志方法包装调用，包装调用时调用栈多出一层
 }
 
 func NewLogger(opts ...Option) *defaultLogger {
@@ -69,6 +80,8 @@ func NewLogger(opts ...Option) *defaultLogger {
 
 	l := &defaultLogger{}
 	l.opts = o
+	l.callSkip = o.callSkip
+	l.wrapped = true
 	l.pool = &sync.Pool{New: func() any { return &Entity{} }}
 
 	syncers := make(map[string]Syncer, len(l.opts.syncers))
@@ -199,6 +212,47 @@ func (l *defaultLogger) Panicf(format string, a ...any) {
 	l.print(LevelPanic, true, fmt.Sprintf(format, a...))
 }
 
+// With 创建携带字段的子日志记录器
+// 子日志记录器由调用方直接使用，父日志记录器经由全局日志方法包装调用时，子日志记录器的调用栈跳过深度减少一层
+func (l *defaultLogger) With(fields ...Field) FieldLogger {
+	if len(fields) == 0 {
+		return l
+	}
+
+	child := &defaultLogger{
+		opts:      l.opts,
+		pool:      l.pool,
+		terminals: l.terminals,
+		fields:    make([]Field, 0, len(l.fields)+len(fields)),
+		callSkip:  l.callSkip,
+	}
+
+	if l.wrapped {
+		child.callSkip--
+	}
+
+	child.fields = append(child.fields, l.fields...)
+	child.fields = append(child.fields, fields...)
+
+	return child
+}
+
+// 获取经由全局日志方法包装调用的日志记录器
+func (l *defaultLogger) wrap() *defaultLogger {
+	if l.wrapped {
+		return l
+	}
+
+	return &defaultLogger{
+		opts:      l.opts,
+		pool:      l.pool,
+		terminals: l.terminals,
+		fields:    l.fields,
+		callSkip:  l.callSkip + 1,
+		wrapped:   true,
+	}
+}
+
 // Close 关闭日志
 func (l *defaultLogger) Close() error {
 	eg, _ := errgroup.WithContext(context.Background())
@@ -252,6 +306,7 @@ func (l *defaultLogger) releaseEntity(e *Entity) {
 	e.Message = ""
 	e.Caller = ""
 	e.Frames = nil
+	e.Fields = nil
 
 	l.pool.Put(e)
 }
@@ -263,6 +318,7 @@ func (l *defaultLogger) makeEntity(level Level, isOutStack bool, a ...any) *Enti
 	e.Time = e.Now.Format(l.opts.timeFormat)
 	e.Level = level
 	e.Message = l.makeMessage(a...)
+	e.Fields = l.fields
 
 	if isOutStack && l.opts.stackLevel != "" && l.opts.stackLevel != LevelNone && level.Priority() >= l.opts.stackLevel.Priority() {
 		e.Caller, e.Frames = l.makeStack(stack.Full)
@@ -290,7 +346,7 @@ func (l *defaultLogger) makeMessage(a ...any) (message string) {
 
 // 构建堆栈信息
 func (l *defaultLogger) makeStack(depth stack.Depth) (string, []runtime.Frame) {
-	st := stack.Callers(3+l.opts.callSkip, depth)
+	st := stack.Callers(3+l.callSkip, depth)
 	defer st.Free()
 
 	var (
@@ -79,6 +79,10 @@ func (s *Syncer) makeLog(entity *log.Entity) *cls.Log {
 		s.rawPool.Put(raw)
 	}()
 
+	for _, field := range entity.Fields {
+		raw[field.Key] = fmt.Sprint(field.Value)
+	}
+
 	raw[fieldKeyLevel] = string(entity.Level[:4])
 	raw[fieldKeyTime] = entity.Time
 	raw[fieldKeyFile] = entity.Caller
@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+var globalRegistry atomic.Value
+
+func init() {
+	SetRegistry(NewRegistry())
+}
+
+// Registry 指标注册器
+// 相同名称的指标应只创建一次，重复获取时返回已创建的指标
+type Registry interface {
+	// Counter 获取或创建计数器
+	Counter(name, help string, labels ...string) Counter
+	// Gauge 获取或创建仪表盘
+	Gauge(name, help string, labels ...string) Gauge
+	// Histogram 获取或创建直方图，buckets为空时使用默认分桶
+	Histogram(name, help string, buckets []float64, labels ...string) Histogram
+	// Handler 获取指标导出处理器
+	Handler() http.Handler
+}
+
+// Counter 计数器，只增不减
+type Counter interface {
+	// Add 增加计数，values为标签值，需与创建时的标签一一对应
+	Add(delta float64, values ...string)
+}
+
+// Gauge 仪表盘，可增可减
+type Gauge interface {
+	// Set 设置数值，values为标签值，需与创建时的标签一一对应
+	Set(value float64, values ...string)
+	// Add 增加数值，delta为负数时减少数值
+	Add(delta float64, values ...string)
+}
+
+// Histogram 直方图
+type Histogram interface {
+	// Observe 观测数值，values为标签值，需与创建时的标签一一对应
+	Observe(value float64, values ...string)
+}
+
+type registryHolder struct {
+	registry Registry
+}
+
+// SetRegistry 设置全局指标注册器
+func SetRegistry(registry Registry) {
+	globalRegistry.Store(registryHolder{registry: registry})
+}
+
+// GetRegistry 获取全局指标注册器
+func GetRegistry() Registry {
+	return globalRegistry.Load().(registryHolder).registry
+}
+
+// Handler 获取全局指标注册器的导出处理器
+func Handler() http.Handler {
+	return GetRegistry().Handler()
+}
+
+// NewCounter 创建绑定到全局指标注册器的计数器
+// 计数器在首次使用时才向全局指标注册器注册，全局指标注册器变更后将自动注册到新的注册器中
+func NewCounter(name, help string, labels ...string) Counter {
+	return &counter{lazy: lazy[Counter]{create: func(r Registry) Counter {
+		return r.Counter(name, help, labels...)
+	}}}
+}
+
+// NewGauge 创建绑定到全局指标注册器的仪表盘
+// 仪表盘在首次使用时才向全局指标注册器注册，全局指标注册器变更后将自动注册到新的注册器中
+func NewGauge(name, help string, labels ...string) Gauge {
+	return &gauge{lazy: lazy[Gauge]{create: func(r Registry) Gauge {
+		return r.Gauge(name, help, labels...)
+	}}}
+}
+
+// NewHistogram 创建绑定到全局指标注册器的直方图
+// 直方图在首次使用时才向全局指标注册器注册，全局指标注册器变更后将自动注册到新的注册器中
+func NewHistogram(name, help string, buckets []float64, labels ...string) Histogram {
+	return &histogram{lazy: lazy[Histogram]{create: func(r Registry) Histogram {
+		return r.Histogram(name, help, buckets, labels...)
+	}}}
+}
+
+type counter struct {
+	lazy[Counter]
+}
+
+// Add 增加计数
+func (c *counter) Add(delta float64, values ...string) {
+	c.load().Add(delta, values...)
+}
+
+type gauge struct {
+	lazy[Gauge]
+}
+
+// Set 设置数值
+func (g *gauge) Set(value float64, values ...string) {
+	g.load().Set(value, values...)
+}
+
+// Add 增加数值
+func (g *gauge) Add(delta float64, values ...string) {
+	g.load().Add(delta, values...)
+}
+
+type histogram struct {
+	lazy[Histogram]
+}
+
+// Observe 观测数值
+func (h *histogram) Observe(value float64, values ...string) {
+	h.load().Observe(value, values...)
+}
+
+type binding[T any] struct {
+	registry Registry
+	metric   T
+}
+
+// 延迟绑定到全局指标注册器的指标
+type lazy[T any] struct {
+	create  func(r Registry) T
+	binding atomic.Pointer[binding[T]]
+}
+
+// 加载当前全局指标注册器中的指标
+func (l *lazy[T]) load() T {
+	r := GetRegistry()
+
+	if b := l.binding.Load(); b != nil && b.registry == r {
+		return b.metric
+	}
+
+	b := &binding[T]{registry: r, metric: l.create(r)}
+	l.binding.Store(b)
+
+	return b.metric
+}
@@ -0,0 +1,87 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dobyte/due/v2/metrics"
+)
+
+func TestRegistry_Handler(t *testing.T) {
+	r := metrics.NewRegistry()
+
+	counter := r.Counter("test_requests_total", "Total requests.", "route")
+	counter.Add(1, "1")
+	counter.Add(2, "1")
+	counter.Add(1, "2")
+
+	gauge := r.Gauge("test_connections", "Current connections.")
+	gauge.Add(3)
+	gauge.Add(-1)
+
+	histogram := r.Histogram("test_duration_seconds", "Request duration.", []float64{0.1, 1}, "route")
+	histogram.Observe(0.05, "1")
+	histogram.Observe(0.5, "1")
+	histogram.Observe(5, "1")
+
+	server := httptest.NewServer(r.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := string(body)
+
+	for _, line := range []string{
+		"# TYPE test_connections gauge",
+		"test_connections 2",
+		"# TYPE test_requests_total counter",
+		`test_requests_total{route="1"} 3`,
+		`test_requests_total{route="2"} 1`,
+		"# TYPE test_duration_seconds histogram",
+		`test_duration_seconds_bucket{route="1",le="0.1"} 1`,
+		`test_duration_seconds_bucket{route="1",le="1"} 2`,
+		`test_duration_seconds_bucket{route="1",le="+Inf"} 3`,
+		`test_duration_seconds_sum{route="1"} 5.55`,
+		`test_duration_seconds_count{route="1"} 3`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("missing line %q in:\n%s", line, text)
+		}
+	}
+}
+
+func TestNewCounter(t *testing.T) {
+	prev := metrics.GetRegistry()
+	defer metrics.SetRegistry(prev)
+
+	counter := metrics.NewCounter("test_lazy_total", "Lazy counter.")
+
+	r1 := metrics.NewRegistry()
+	metrics.SetRegistry(r1)
+	counter.Add(1)
+
+	r2 := metrics.NewRegistry()
+	metrics.SetRegistry(r2)
+	counter.Add(1)
+
+	for _, r := range []metrics.Registry{r1, r2} {
+		rec := httptest.NewRecorder()
+		r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		if !strings.Contains(rec.Body.String(), "test_lazy_total 1\n") {
+			t.Errorf("unexpected metrics output:\n%s", rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dobyte/due/v2/log"
+)
+
+const (
+	counterKind   = "counter"
+	gaugeKind     = "gauge"
+	histogramKind = "histogram"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets 默认的直方图分桶，单位为秒，适用于统计请求耗时
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type registry struct {
+	rw       sync.RWMutex
+	families map[string]*family
+}
+
+// NewRegistry 创建基于内存的指标注册器，以Prometheus文本格式导出指标
+func NewRegistry() Registry {
+	return &registry{families: make(map[string]*family)}
+}
+
+// Counter 获取或创建计数器
+func (r *registry) Counter(name, help string, labels ...string) Counter {
+	return r.family(counterKind, name, help, nil, labels)
+}
+
+// Gauge 获取或创建仪表盘
+func (r *registry) Gauge(name, help string, labels ...string) Gauge {
+	return r.family(gaugeKind, name, help, nil, labels)
+}
+
+// Histogram 获取或创建直方图
+func (r *registry) Histogram(name, help string, buckets []float64, labels ...string) Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return r.family(histogramKind, name, help, buckets, labels)
+}
+
+// Handler 获取指标导出处理器
+func (r *registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+
+		if err := r.write(w); err != nil {
+			log.Warnf("write metrics failed: %v", err)
+		}
+	})
+}
+
+// 获取或创建指标族
+func (r *registry) family(kind, name, help string, buckets []float64, labels []string) *family {
+	r.rw.RLock()
+	f, ok := r.families[name]
+	r.rw.RUnlock()
+
+	if !ok {
+		r.rw.Lock()
+		if f, ok = r.families[name]; !ok {
+			f = &family{
+				kind:    kind,
+				name:    name,
+				help:    help,
+				labels:  slices.Clone(labels),
+				buckets: buckets,
+				series:  make(map[string]*series),
+			}
+			r.families[name] = f
+		}
+		r.rw.Unlock()
+	}
+
+	if f.kind != kind {
+		log.Errorf("metric %s is already registered as %s", name, f.kind)
+		return &family{kind: kind, name: name, discard: true}
+	}
+
+	return f
+}
+
+// 以Prometheus文本格式写入所有指标
+func (r *registry) write(w io.Writer) error {
+	r.rw.RLock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.rw.RUnlock()
+
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	bw := bufio.NewWriter(w)
+
+	for _, f := range families {
+		f.write(bw)
+	}
+
+	return bw.Flush()
+}
+
+// 指标族，同名且标签相同的一组指标
+type family struct {
+	kind    string
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+	discard bool // 是否丢弃观测值，指标类型冲突时使用
+	rw      sync.RWMutex
+	series  map[string]*series
+}
+
+// Add 增加数值
+func (f *family) Add(delta float64, values ...string) {
+	if s := f.lookup(values); s != nil {
+		s.value.add(delta)
+	}
+}
+
+// Set 设置数值
+func (f *family) Set(value float64, values ...string) {
+	if s := f.lookup(values); s != nil {
+		s.value.store(value)
+	}
+}
+
+// Observe 观测数值
+func (f *family) Observe(value float64, values ...string) {
+	s := f.lookup(values)
+	if s == nil {
+		return
+	}
+
+	if i := sort.SearchFloat64s(f.buckets, value); i < len(s.counts) {
+		s.counts[i].Add(1)
+	}
+
+	s.value.add(value)
+	s.count.Add(1)
+}
+
+// 查找或创建标签值对应的时间序列
+func (f *family) lookup(values []string) *series {
+	if f.discard || len(values) != len(f.labels) {
+		return nil
+	}
+
+	key := strings.Join(values, "\xff")
+
+	f.rw.RLock()
+	s, ok := f.series[key]
+	f.rw.RUnlock()
+
+	if ok {
+		return s
+	}
+
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	if s, ok = f.series[key]; !ok {
+		s = &series{values: slices.Clone(values)}
+		if f.kind == histogramKind {
+			s.counts = make([]atomic.Uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+
+	return s
+}
+
+// 写入指标族
+func (f *family) write(w *bufio.Writer) {
+	f.rw.RLock()
+	list := make([]*series, 0, len(f.series))
+	for _, s := range f.series {
+		list = append(list, s)
+	}
+	f.rw.RUnlock()
+
+	if len(list) == 0 {
+		return
+	}
+
+	sort.Slice(list, func(i, j int) bool { return slices.Compare(list[i].values, list[j].values) < 0 })
+
+	w.WriteString("# HELP " + f.name + " " + escape(f.help, false) + "\n")
+	w.WriteString("# TYPE " + f.name + " " + f.kind + "\n")
+
+	for _, s := range list {
+		if f.kind != histogramKind {
+			f.writeSample(w, f.name, s.values, "", s.value.load())
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i].Load()
+			f.writeSample(w, f.name+"_bucket", s.values, formatFloat(bound), float64(cumulative))
+		}
+
+		count := s.count.Load()
+		f.writeSample(w, f.name+"_bucket", s.values, "+Inf", float64(count))
+		f.writeSample(w, f.name+"_sum", s.values, "", s.value.load())
+		f.writeSample(w, f.name+"_count", s.values, "", float64(count))
+	}
+}
+
+// 写入样本，le不为空时追加直方图分桶标签
+func (f *family) writeSample(w *bufio.Writer, name string, values []string, le string, value float64) {
+	w.WriteString(name)
+
+	if len(values) > 0 || le != "" {
+		w.WriteByte('{')
+
+		for i, label := range f.labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label + `="` + escape(values[i], true) + `"`)
+		}
+
+		if le != "" {
+			if len(values) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(`le="` + le + `"`)
+		}
+
+		w.WriteByte('}')
+	}
+
+	w.WriteString(" " + formatFloat(value) + "\n")
+}
+
+// 时间序列
+type series struct {
+	values []string        // 标签值
+	value  float64Value    // 计数器、仪表盘的数值或直方图的观测值总和
+	counts []atomic.Uint64 // 直方图各分桶的观测次数
+	count  atomic.Uint64   // 直方图的观测次数
+}
+
+// 支持原子操作的浮点数
+type float64Value struct {
+	bits atomic.Uint64
+}
+
+func (v *float64Value) load() float64 {
+	return math.Float64frombits(v.bits.Load())
+}
+
+func (v *float64Value) store(value float64) {
+	v.bits.Store(math.Float64bits(value))
+}
+
+func (v *float64Value) add(delta float64) {
+	for {
+		old := v.bits.Load()
+		if v.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// 格式化浮点数
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// 转义帮助信息及标签值
+func escape(s string, quote bool) string {
+	if quote {
+		return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+	}
+
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
@@ -47,6 +47,8 @@ type (
 		RemoteIP() (string, error)
 		// RemoteAddr 获取远端地址
 		RemoteAddr() (net.Addr, error)
+		// QueueDepth 获取写入队列中待发送的消息数
+		QueueDepth() int
 	}
 
 	Attr interface {
@@ -9,6 +9,7 @@ package kcp
 
 import (
 	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/network"
 	"time"
 )
 
@@ -18,6 +19,8 @@ const (
 	defaultServerMaxConnNum         = 5000
 	defaultServerHeartbeatInterval  = "10s"
 	defaultServerHeartbeatMechanism = "resp"
+	defaultServerWriteQueueSize     = 4096
+	defaultServerSlowConsumerPolicy = "block"
 )
 
 const (
@@ -26,6 +29,9 @@ const (
 	defaultServerMaxConnNumKey         = "etc.network.kcp.server.maxConnNum"
 	defaultServerHeartbeatIntervalKey  = "etc.network.kcp.server.heartbeatInterval"
 	defaultServerHeartbeatMechanismKey = "etc.network.kcp.server.heartbeatMechanism"
+	defaultServerWriteQueueSizeKey     = "etc.network.kcp.server.writeQueueSize"
+	defaultServerSlowConsumerPolicyKey = "etc.network.kcp.server.slowConsumerPolicy"
+	defaultServerCriticalRoutesKey     = "etc.network.kcp.server.criticalRoutes"
 )
 
 const (
@@ -38,10 +44,13 @@ type HeartbeatMechanism string
 type ServerOption func(o *serverOptions)
 
 type serverOptions struct {
-	addr               string             // 监听地址
-	maxConnNum         int                // 最大连接数
-	heartbeatInterval  time.Duration      // 心跳检测间隔时间，默认10s
-	heartbeatMechanism HeartbeatMechanism // 心跳机制，默认resp
+	addr               string                     // 监听地址
+	maxConnNum         int                        // 最大连接数
+	heartbeatInterval  time.Duration              // 心跳检测间隔时间，默认10s
+	heartbeatMechanism HeartbeatMechanism         // 心跳机制，默认resp
+	writeQueueSize     int                        // 写入队列大小，默认4096
+	slowConsumerPolicy network.SlowConsumerPolicy // 慢消费者策略，写入队列已满时生效，默认block
+	criticalRoutes     []int32                    // 关键路由，慢消费者策略为drop-non-critical时，关键路由的消息不会被丢弃
 }
 
 func defaultServerOptions() *serverOptions {
@@ -50,6 +59,9 @@ func defaultServerOptions() *serverOptions {
 		maxConnNum:         etc.Get(defaultServerMaxConnNumKey, defaultServerMaxConnNum).Int(),
 		heartbeatInterval:  etc.Get(defaultServerHeartbeatIntervalKey, defaultServerHeartbeatInterval).Duration(),
 		heartbeatMechanism: HeartbeatMechanism(etc.Get(defaultServerHeartbeatMechanismKey, defaultServerHeartbeatMechanism).String()),
+		writeQueueSize:     etc.Get(defaultServerWriteQueueSizeKey, defaultServerWriteQueueSize).Int(),
+		slowConsumerPolicy: network.SlowConsumerPolicy(etc.Get(defaultServerSlowConsumerPolicyKey, defaultServerSlowConsumerPolicy).String()),
+		criticalRoutes:     etc.Get(defaultServerCriticalRoutesKey).Int32s(),
 	}
 }
 
@@ -72,3 +84,14 @@ func WithServerHeartbeatInterval(heartbeatInterval time.Duration) ServerOption {
 func WithServerHeartbeatMechanism(heartbeatMechanism HeartbeatMechanism) ServerOption {
 	return func(o *serverOptions) { o.heartbeatMechanism = heartbeatMechanism }
 }
+
+// WithServerWriteQueueSize 设置每个连接的写入队列大小
+func WithServerWriteQueueSize(writeQueueSize int) ServerOption {
+	return func(o *serverOptions) { o.writeQueueSize = writeQueueSize }
+}
+
+// WithServerSlowConsumerPolicy 设置慢消费者策略，写入队列已满时生效
+// 策略为drop-non-critical时，可通过criticalRoutes设置不可丢弃的关键路由
+func WithServerSlowConsumerPolicy(slowConsumerPolicy network.SlowConsumerPolicy, criticalRoutes ...int32) ServerOption {
+	return func(o *serverOptions) { o.slowConsumerPolicy, o.criticalRoutes = slowConsumerPolicy, criticalRoutes }
+}
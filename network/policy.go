@@ -0,0 +1,121 @@
+package network
+
+import (
+	"slices"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/packet"
+)
+
+const (
+	BlockPolicy           SlowConsumerPolicy = "block"             // 阻塞等待，直到写入队列有空闲位置
+	DropOldestPolicy      SlowConsumerPolicy = "drop-oldest"       // 丢弃写入队列中最旧的消息
+	DropNonCriticalPolicy SlowConsumerPolicy = "drop-non-critical" // 丢弃非关键路由的消息，关键路由的消息将挤出写入队列中最旧的消息
+	DisconnectPolicy      SlowConsumerPolicy = "disconnect"        // 断开连接
+)
+
+// SlowConsumerPolicy 慢消费者策略，连接的写入队列已满时生效
+type SlowConsumerPolicy string
+
+// Enqueue 将消息写入连接的写入队列，写入队列已满时按慢消费者策略处理
+// 调用方需保证写入期间写入队列不会被关闭；retained用于检测写入队列中不可丢弃的元素（如关闭信号），丢弃时遇到此类元素表示连接正在关闭
+// 返回errors.ErrWriteQueueFull表示消息已被丢弃，策略为DisconnectPolicy时调用方应断开连接
+func Enqueue[T any](queue chan T, item T, msg []byte, policy SlowConsumerPolicy, criticalRoutes []int32, retained func(T) bool) error {
+	select {
+	case queue <- item:
+		return nil
+	default:
+	}
+
+	switch policy {
+	case DropOldestPolicy:
+		return dropOldest(queue, item, retained)
+	case DropNonCriticalPolicy:
+		if !IsCriticalMessage(msg, criticalRoutes) {
+			return errors.ErrWriteQueueFull
+		}
+
+		// 关键消息不阻塞等待，避免调用方持有连接锁时阻塞导致连接无法关闭
+		return dropOldest(queue, item, retained)
+	case DisconnectPolicy:
+		return errors.ErrWriteQueueFull
+	}
+
+	queue <- item
+
+	return nil
+}
+
+// 丢弃写入队列中最旧的元素后写入
+func dropOldest[T any](queue chan T, item T, retained func(T) bool) error {
+	for {
+		select {
+		case old := <-queue:
+			if retained != nil && retained(old) {
+				requeue(queue, old, retained)
+				return errors.ErrConnectionHanged
+			}
+		default:
+		}
+
+		select {
+		case queue <- item:
+			return nil
+		default:
+		}
+	}
+}
+
+// 将不可丢弃的元素非阻塞地放回写入队列
+// 调用方可能持有连接锁，放回期间写入队列被其他写入方填满时，继续丢弃最旧的可丢弃元素以腾出位置，而不是阻塞等待
+func requeue[T any](queue chan T, item T, retained func(T) bool) {
+	items := []T{item}
+
+	for len(items) > 0 {
+		select {
+		case queue <- items[0]:
+			items = items[1:]
+			continue
+		default:
+		}
+
+		select {
+		case old := <-queue:
+			if retained(old) {
+				items = append(items, old)
+			}
+		default:
+		}
+	}
+}
+
+// IsCriticalMessage 检测消息是否属于关键路由
+// 仅解析数据包头与路由号，不解压缩消息内容；批量消息中任一消息属于关键路由即视为关键消息；无法解析的消息视为关键消息，避免误丢
+func IsCriticalMessage(msg []byte, routes []int32) bool {
+	isBatch, err := packet.CheckBatch(msg)
+	if err != nil {
+		return true
+	}
+
+	if !isBatch {
+		route, err := packet.ExtractRoute(msg)
+		if err != nil {
+			return true
+		}
+
+		return slices.Contains(routes, route)
+	}
+
+	packets, err := packet.UnpackBatch(msg)
+	if err != nil {
+		return true
+	}
+
+	for _, p := range packets {
+		if IsCriticalMessage(p, routes) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+package network_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+)
+
+const closeSig = -1
+
+func packMessage(t *testing.T, route int32) []byte {
+	t.Helper()
+
+	msg, err := packet.PackMessage(&packet.Message{Route: route, Buffer: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return msg
+}
+
+func isCloseSig(item int) bool {
+	return item == closeSig
+}
+
+func TestEnqueue_DropOldest(t *testing.T) {
+	queue := make(chan int, 2)
+
+	for i := 1; i <= 3; i++ {
+		if err := network.Enqueue(queue, i, nil, network.DropOldestPolicy, nil, isCloseSig); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if first, second := <-queue, <-queue; first != 2 || second != 3 {
+		t.Fatalf("unexpected queue, want: [2 3] got: [%d %d]", first, second)
+	}
+
+	// 关闭信号不可被丢弃
+	queue <- closeSig
+	queue <- 1
+
+	if err := network.Enqueue(queue, 2, nil, network.DropOldestPolicy, nil, isCloseSig); !errors.Is(err, errors.ErrConnectionHanged) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestEnqueue_DropOldestRequeue(t *testing.T) {
+	queue := make(chan int, 2)
+	queue <- closeSig
+	queue <- 1
+
+	// 取出关闭信号后，其他写入方抢占了腾出的位置
+	retained := func(item int) bool {
+		if item == closeSig {
+			queue <- 3
+		}
+
+		return item == closeSig
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- network.Enqueue(queue, 2, nil, network.DropOldestPolicy, nil, retained)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errors.ErrConnectionHanged) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("enqueue is blocked while requeuing retained item")
+	}
+
+	if first, second := <-queue, <-queue; first != 3 || second != closeSig {
+		t.Fatalf("unexpected queue, want: [3 %d] got: [%d %d]", closeSig, first, second)
+	}
+}
+
+func TestEnqueue_DropNonCritical(t *testing.T) {
+	const critical = 10
+
+	queue := make(chan int, 1)
+	queue <- 1
+
+	if err := network.Enqueue(queue, 2, packMessage(t, 1), network.DropNonCriticalPolicy, []int32{critical}, isCloseSig); !errors.Is(err, errors.ErrWriteQueueFull) {
+		t.Fatalf("non-critical message should be dropped, err: %v", err)
+	}
+
+	// 关键消息不阻塞，挤出最旧的消息
+	if err := network.Enqueue(queue, 3, packMessage(t, critical), network.DropNonCriticalPolicy, []int32{critical}, isCloseSig); err != nil {
+		t.Fatal(err)
+	}
+
+	if item := <-queue; item != 3 {
+		t.Fatalf("critical message is not enqueued, got: %d", item)
+	}
+}
+
+func TestEnqueue_Disconnect(t *testing.T) {
+	queue := make(chan int, 1)
+
+	if err := network.Enqueue(queue, 1, nil, network.DisconnectPolicy, nil, isCloseSig); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := network.Enqueue(queue, 2, nil, network.DisconnectPolicy, nil, isCloseSig); !errors.Is(err, errors.ErrWriteQueueFull) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIsCriticalMessage(t *testing.T) {
+	routes := []int32{10}
+
+	if !network.IsCriticalMessage(packMessage(t, 10), routes) {
+		t.Fatal("message with critical route should be critical")
+	}
+
+	if network.IsCriticalMessage(packMessage(t, 1), routes) {
+		t.Fatal("message with normal route should not be critical")
+	}
+
+	batch, err := packet.MergeBatch(packMessage(t, 1), packMessage(t, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !network.IsCriticalMessage(batch, routes) {
+		t.Fatal("batch containing critical route should be critical")
+	}
+
+	if !network.IsCriticalMessage([]byte{1, 2}, routes) {
+		t.Fatal("invalid message should be treated as critical")
+	}
+}
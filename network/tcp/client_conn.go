@@ -112,6 +112,14 @@ func (c *clientConn) Push(msg []byte) (err error) {
 	return
 }
 
+// QueueDepth 获取写入队列中待发送的消息数
+func (c *clientConn) QueueDepth() int {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	return len(c.chWrite)
+}
+
 // State 获取连接状态
 func (c *clientConn) State() network.ConnState {
 	return network.ConnState(atomic.LoadInt32(&c.state))
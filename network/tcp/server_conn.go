@@ -81,18 +81,26 @@ func (c *serverConn) Send(msg []byte) (err error) {
 
 // Push 发送消息（异步）
 func (c *serverConn) Push(msg []byte) (err error) {
-	c.rw.RLock()
-	defer c.rw.RUnlock()
+	if err = c.push(msg); errors.Is(err, errors.ErrWriteQueueFull) && c.connMgr.server.opts.slowConsumerPolicy == network.DisconnectPolicy {
+		log.Warnf("connection write queue is full, disconnect the slow consumer, cid: %d uid: %d", c.id, c.UID())
 
-	if err = c.checkState(); err != nil {
-		return
+		// 异步断开连接，避免调用方持有会话锁时触发断开回调造成死锁
+		xcall.Go(func() {
+			_ = c.forceClose(true)
+		})
 	}
 
-	c.chWrite <- chWrite{typ: dataPacket, msg: msg}
-
 	return
 }
 
+// QueueDepth 获取写入队列中待发送的消息数
+func (c *serverConn) QueueDepth() int {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	return len(c.chWrite)
+}
+
 // State 获取连接状态
 func (c *serverConn) State() network.ConnState {
 	return network.ConnState(atomic.LoadInt32(&c.state))
@@ -161,6 +169,22 @@ func (c *serverConn) RemoteAddr() (net.Addr, error) {
 	return conn.RemoteAddr(), nil
 }
 
+// 写入消息到写入队列，写入队列已满时按慢消费者策略处理
+func (c *serverConn) push(msg []byte) error {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	if err := c.checkState(); err != nil {
+		return err
+	}
+
+	opts := c.connMgr.server.opts
+
+	return network.Enqueue(c.chWrite, chWrite{typ: dataPacket, msg: msg}, msg, opts.slowConsumerPolicy, opts.criticalRoutes, func(r chWrite) bool {
+		return r.typ == closeSig // 关闭信号不可丢弃
+	})
+}
+
 // 检测连接状态
 func (c *serverConn) checkState() error {
 	switch network.ConnState(atomic.LoadInt32(&c.state)) {
@@ -206,7 +230,7 @@ func (c *serverConn) init(cm *serverConnMgr, id int64, conn net.Conn) {
 	c.attr = &attr{}
 	c.conn = conn
 	c.connMgr = cm
-	c.chWrite = make(chan chWrite, 4096)
+	c.chWrite = make(chan chWrite, c.writeQueueSize())
 	c.done = make(chan struct{})
 	c.close = make(chan struct{})
 	c.lastHeartbeatTime = xtime.Now().UnixNano()
@@ -225,6 +249,15 @@ func (c *serverConn) init(cm *serverConnMgr, id int64, conn net.Conn) {
 	}
 }
 
+// 获取写入队列大小
+func (c *serverConn) writeQueueSize() int {
+	if size := c.connMgr.server.opts.writeQueueSize; size > 0 {
+		return size
+	}
+
+	return defaultServerWriteQueueSize
+}
+
 // 重置连接
 func (c *serverConn) reset() {
 	c.attr = nil
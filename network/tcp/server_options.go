@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/network"
 )
 
 const (
@@ -12,6 +13,8 @@ const (
 	defaultServerHeartbeatInterval  = "10s"
 	defaultServerHeartbeatMechanism = "resp"
 	defaultServerAuthorizeTimeout   = "0s"
+	defaultServerWriteQueueSize     = 4096
+	defaultServerSlowConsumerPolicy = "block"
 )
 
 const (
@@ -22,6 +25,9 @@ const (
 	defaultServerHeartbeatIntervalKey  = "etc.network.tcp.server.heartbeatInterval"
 	defaultServerHeartbeatMechanismKey = "etc.network.tcp.server.heartbeatMechanism"
 	defaultServerAuthorizeTimeoutKey   = "etc.network.tcp.server.authorizeTimeout"
+	defaultServerWriteQueueSizeKey     = "etc.network.tcp.server.writeQueueSize"
+	defaultServerSlowConsumerPolicyKey = "etc.network.tcp.server.slowConsumerPolicy"
+	defaultServerCriticalRoutesKey     = "etc.network.tcp.server.criticalRoutes"
 )
 
 const (
@@ -34,13 +40,16 @@ type HeartbeatMechanism string
 type ServerOption func(o *serverOptions)
 
 type serverOptions struct {
-	addr               string             // 监听地址，默认0.0.0.0:3553
-	certFile           string             // 证书文件
-	keyFile            string             // 秘钥文件
-	maxConnNum         int                // 最大连接数，默认5000
-	heartbeatInterval  time.Duration      // 心跳检测间隔时间，默认10s
-	heartbeatMechanism HeartbeatMechanism // 心跳机制，默认resp
-	authorizeTimeout   time.Duration      // 授权超时时间，默认0s，不检测
+	addr               string                     // 监听地址，默认0.0.0.0:3553
+	certFile           string                     // 证书文件
+	keyFile            string                     // 秘钥文件
+	maxConnNum         int                        // 最大连接数，默认5000
+	heartbeatInterval  time.Duration              // 心跳检测间隔时间，默认10s
+	heartbeatMechanism HeartbeatMechanism         // 心跳机制，默认resp
+	authorizeTimeout   time.Duration              // 授权超时时间，默认0s，不检测
+	writeQueueSize     int                        // 写入队列大小，默认4096
+	slowConsumerPolicy network.SlowConsumerPolicy // 慢消费者策略，写入队列已满时生效，默认block
+	criticalRoutes     []int32                    // 关键路由，慢消费者策略为drop-non-critical时，关键路由的消息不会被丢弃
 }
 
 func defaultServerOptions() *serverOptions {
@@ -52,6 +61,9 @@ func defaultServerOptions() *serverOptions {
 		heartbeatInterval:  etc.Get(defaultServerHeartbeatIntervalKey, defaultServerHeartbeatInterval).Duration(),
 		heartbeatMechanism: HeartbeatMechanism(etc.Get(defaultServerHeartbeatMechanismKey, defaultServerHeartbeatMechanism).String()),
 		authorizeTimeout:   etc.Get(defaultServerAuthorizeTimeoutKey, defaultServerAuthorizeTimeout).Duration(),
+		writeQueueSize:     etc.Get(defaultServerWriteQueueSizeKey, defaultServerWriteQueueSize).Int(),
+		slowConsumerPolicy: network.SlowConsumerPolicy(etc.Get(defaultServerSlowConsumerPolicyKey, defaultServerSlowConsumerPolicy).String()),
+		criticalRoutes:     etc.Get(defaultServerCriticalRoutesKey).Int32s(),
 	}
 }
 
@@ -84,3 +96,14 @@ func WithServerHeartbeatMechanism(heartbeatMechanism HeartbeatMechanism) ServerO
 func WithServerAuthorizeTimeout(authorizeTimeout time.Duration) ServerOption {
 	return func(o *serverOptions) { o.authorizeTimeout = authorizeTimeout }
 }
+
+// WithServerWriteQueueSize 设置每个连接的写入队列大小
+func WithServerWriteQueueSize(writeQueueSize int) ServerOption {
+	return func(o *serverOptions) { o.writeQueueSize = writeQueueSize }
+}
+
+// WithServerSlowConsumerPolicy 设置慢消费者策略，写入队列已满时生效
+// 策略为drop-non-critical时，可通过criticalRoutes设置不可丢弃的关键路由
+func WithServerSlowConsumerPolicy(slowConsumerPolicy network.SlowConsumerPolicy, criticalRoutes ...int32) ServerOption {
+	return func(o *serverOptions) { o.slowConsumerPolicy, o.criticalRoutes = slowConsumerPolicy, criticalRoutes }
+}
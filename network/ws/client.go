@@ -24,7 +24,8 @@ func NewClient(opts ...ClientOption) network.Client {
 	}
 
 	return &client{opts: o, dialer: &websocket.Dialer{
-		HandshakeTimeout: o.handshakeTimeout,
+		HandshakeTimeout:  o.handshakeTimeout,
+		EnableCompression: o.compression,
 	}}
 }
 
@@ -10,12 +10,14 @@ const (
 	defaultClientUrl               = "ws://127.0.0.1:3553"
 	defaultClientHandshakeTimeout  = "10s"
 	defaultClientHeartbeatInterval = "10s"
+	defaultClientCompression       = false
 )
 
 const (
 	defaultClientUrlKey               = "etc.network.ws.client.url"
 	defaultClientHandshakeTimeoutKey  = "etc.network.ws.client.handshakeTimeout"
 	defaultClientHeartbeatIntervalKey = "etc.network.ws.client.heartbeatInterval"
+	defaultClientCompressionKey       = "etc.network.ws.client.compression"
 )
 
 type ClientOption func(o *clientOptions)
@@ -24,6 +26,7 @@ type clientOptions struct {
 	url               string        // 拨号地址
 	handshakeTimeout  time.Duration // 握手超时时间
 	heartbeatInterval time.Duration // 心跳间隔时间，默认10s
+	compression       bool          // 是否协商permessage-deflate压缩，默认false
 }
 
 func defaultClientOptions() *clientOptions {
@@ -31,6 +34,7 @@ func defaultClientOptions() *clientOptions {
 		url:               etc.Get(defaultClientUrlKey, defaultClientUrl).String(),
 		handshakeTimeout:  etc.Get(defaultClientHandshakeTimeoutKey, defaultClientHandshakeTimeout).Duration(),
 		heartbeatInterval: etc.Get(defaultClientHeartbeatIntervalKey, defaultClientHeartbeatInterval).Duration(),
+		compression:       etc.Get(defaultClientCompressionKey, defaultClientCompression).Bool(),
 	}
 }
 
@@ -48,3 +52,8 @@ func WithClientHandshakeTimeout(handshakeTimeout time.Duration) ClientOption {
 func WithClientHeartbeatInterval(heartbeatInterval time.Duration) ClientOption {
 	return func(o *clientOptions) { o.heartbeatInterval = heartbeatInterval }
 }
+
+// WithClientCompression 设置是否协商permessage-deflate压缩
+func WithClientCompression(compression bool) ClientOption {
+	return func(o *clientOptions) { o.compression = compression }
+}
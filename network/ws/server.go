@@ -109,7 +109,7 @@ func (s *server) serve() {
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:    4096,
 		WriteBufferSize:   4096,
-		EnableCompression: false,
+		EnableCompression: s.opts.compression,
 		CheckOrigin:       s.opts.checkOrigin,
 	}
 
@@ -130,6 +130,12 @@ func (s *server) serve() {
 			return
 		}
 
+		if s.opts.compression {
+			if err = conn.SetCompressionLevel(s.opts.compressionLevel); err != nil {
+				log.Warnf("websocket set compression level error: %v", err)
+			}
+		}
+
 		if err = s.connMgr.allocate(conn); err != nil {
 			log.Errorf("connection allocate error: %v", err)
 			_ = conn.Close()
@@ -1,10 +1,12 @@
 package ws
 
 import (
+	"compress/flate"
 	"net/http"
 	"time"
 
 	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/network"
 )
 
 const (
@@ -16,6 +18,10 @@ const (
 	defaultServerHeartbeatInterval  = "10s"
 	defaultServerHeartbeatMechanism = "resp"
 	defaultServerAuthorizeTimeout   = "0s"
+	defaultServerCompression        = false
+	defaultServerCompressionLevel   = flate.BestSpeed
+	defaultServerWriteQueueSize     = 4096
+	defaultServerSlowConsumerPolicy = "block"
 )
 
 const (
@@ -29,6 +35,11 @@ const (
 	defaultServerHeartbeatIntervalKey  = "etc.network.ws.server.heartbeatInterval"
 	defaultServerHeartbeatMechanismKey = "etc.network.ws.server.heartbeatMechanism"
 	defaultServerAuthorizeTimeoutKey   = "etc.network.ws.server.authorizeTimeout"
+	defaultServerCompressionKey        = "etc.network.ws.server.compression"
+	defaultServerCompressionLevelKey   = "etc.network.ws.server.compressionLevel"
+	defaultServerWriteQueueSizeKey     = "etc.network.ws.server.writeQueueSize"
+	defaultServerSlowConsumerPolicyKey = "etc.network.ws.server.slowConsumerPolicy"
+	defaultServerCriticalRoutesKey     = "etc.network.ws.server.criticalRoutes"
 )
 
 const (
@@ -43,16 +54,21 @@ type ServerOption func(o *serverOptions)
 type CheckOriginFunc func(r *http.Request) bool
 
 type serverOptions struct {
-	addr               string             // 监听地址
-	maxConnNum         int                // 最大连接数
-	certFile           string             // 证书文件
-	keyFile            string             // 秘钥文件
-	path               string             // 路径，默认为"/"
-	checkOrigin        CheckOriginFunc    // 跨域检测
-	handshakeTimeout   time.Duration      // 握手超时时间，默认10s
-	heartbeatInterval  time.Duration      // 心跳间隔时间，默认10s
-	heartbeatMechanism HeartbeatMechanism // 心跳机制，默认resp
-	authorizeTimeout   time.Duration      // 授权超时时间，默认0s，不检测
+	addr               string                     // 监听地址
+	maxConnNum         int                        // 最大连接数
+	certFile           string                     // 证书文件
+	keyFile            string                     // 秘钥文件
+	path               string                     // 路径，默认为"/"
+	checkOrigin        CheckOriginFunc            // 跨域检测
+	handshakeTimeout   time.Duration              // 握手超时时间，默认10s
+	heartbeatInterval  time.Duration              // 心跳间隔时间，默认10s
+	heartbeatMechanism HeartbeatMechanism         // 心跳机制，默认resp
+	authorizeTimeout   time.Duration              // 授权超时时间，默认0s，不检测
+	compression        bool                       // 是否开启permessage-deflate压缩，默认false
+	compressionLevel   int                        // 压缩级别，默认为flate.BestSpeed
+	writeQueueSize     int                        // 写入队列大小，默认4096
+	slowConsumerPolicy network.SlowConsumerPolicy // 慢消费者策略，写入队列已满时生效，默认block
+	criticalRoutes     []int32                    // 关键路由，慢消费者策略为drop-non-critical时，关键路由的消息不会被丢弃
 }
 
 func defaultServerOptions() *serverOptions {
@@ -83,6 +99,11 @@ func defaultServerOptions() *serverOptions {
 		heartbeatInterval:  etc.Get(defaultServerHeartbeatIntervalKey, defaultServerHeartbeatInterval).Duration(),
 		heartbeatMechanism: HeartbeatMechanism(etc.Get(defaultServerHeartbeatMechanismKey, defaultServerHeartbeatMechanism).String()),
 		authorizeTimeout:   etc.Get(defaultServerAuthorizeTimeoutKey, defaultServerAuthorizeTimeout).Duration(),
+		compression:        etc.Get(defaultServerCompressionKey, defaultServerCompression).Bool(),
+		compressionLevel:   etc.Get(defaultServerCompressionLevelKey, defaultServerCompressionLevel).Int(),
+		writeQueueSize:     etc.Get(defaultServerWriteQueueSizeKey, defaultServerWriteQueueSize).Int(),
+		slowConsumerPolicy: network.SlowConsumerPolicy(etc.Get(defaultServerSlowConsumerPolicyKey, defaultServerSlowConsumerPolicy).String()),
+		criticalRoutes:     etc.Get(defaultServerCriticalRoutesKey).Int32s(),
 	}
 }
 
@@ -130,3 +151,25 @@ func WithServerHeartbeatMechanism(heartbeatMechanism HeartbeatMechanism) ServerO
 func WithServerAuthorizeTimeout(authorizeTimeout time.Duration) ServerOption {
 	return func(o *serverOptions) { o.authorizeTimeout = authorizeTimeout }
 }
+
+// WithServerCompression 设置是否开启permessage-deflate压缩，可选设置压缩级别
+func WithServerCompression(compression bool, level ...int) ServerOption {
+	return func(o *serverOptions) {
+		o.compression = compression
+
+		if len(level) > 0 {
+			o.compressionLevel = level[0]
+		}
+	}
+}
+
+// WithServerWriteQueueSize 设置每个连接的写入队列大小
+func WithServerWriteQueueSize(writeQueueSize int) ServerOption {
+	return func(o *serverOptions) { o.writeQueueSize = writeQueueSize }
+}
+
+// WithServerSlowConsumerPolicy 设置慢消费者策略，写入队列已满时生效
+// 策略为drop-non-critical时，可通过criticalRoutes设置不可丢弃的关键路由
+func WithServerSlowConsumerPolicy(slowConsumerPolicy network.SlowConsumerPolicy, criticalRoutes ...int32) ServerOption {
+	return func(o *serverOptions) { o.slowConsumerPolicy, o.criticalRoutes = slowConsumerPolicy, criticalRoutes }
+}
@@ -1,7 +1,11 @@
 package packet
 
 type Message struct {
-	Seq    int32  // 序列号
-	Route  int32  // 路由ID
-	Buffer []byte // 消息内容
+	Seq     int32             // 序列号
+	Route   int32             // 路由ID
+	Buffer  []byte            // 消息内容
+	Headers map[string]string // 扩展头
+	// 消息内容是否已由调用方压缩，用于先压缩再加密的消息
+	// 打包时仅设置预压缩标识而不再压缩；解包时不会自动解压缩，需由调用方解密后通过DecompressBuffer解压缩
+	Compressed bool
 }
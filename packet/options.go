@@ -2,6 +2,7 @@ package packet
 
 import (
 	"encoding/binary"
+	"github.com/dobyte/due/v2/compress"
 	"github.com/dobyte/due/v2/etc"
 	"strings"
 )
@@ -16,6 +17,26 @@ import (
 // | size(4 byte) = (1 byte + n byte + m byte + x byte) | header(1 byte) | route(n byte) | seq(m byte) | message(x byte) |
 // -----------------------------------------------------------------------------------------------------------------------
 
+// data packet with extensions
+// -----------------------------------------------------------------------------------------------------------------------------------------------------
+// | size(4 byte) = (1 byte + n byte + m byte + y byte + x byte) | header(1 byte) | route(n byte) | seq(m byte) | extensions(y byte) | message(x byte) |
+// -----------------------------------------------------------------------------------------------------------------------------------------------------
+
+// extensions
+// -------------------------------------------------------------------------------------------------------
+// | extensions size(2 byte) | key size(1 byte) | key(k byte) | value size(2 byte) | value(v byte) | ... |
+// -------------------------------------------------------------------------------------------------------
+
+// batch packet
+// ------------------------------------------------------------------------------------------------
+// | size(4 byte) = (1 byte + 2 byte + n byte) | header(1 byte) | count(2 byte) | packets(n byte) |
+// ------------------------------------------------------------------------------------------------
+
+// header
+// ----------------------------------------------------------------------------------------------------------------------------------------
+// | heartbeat flag(1 bit) | compress flag(1 bit) | extension flag(1 bit) | batch flag(1 bit) | precompress flag(1 bit) | reserved(3 bit) |
+// ----------------------------------------------------------------------------------------------------------------------------------------
+
 const (
 	littleEndian = "little"
 	bigEndian    = "big"
@@ -29,6 +50,14 @@ const (
 	defaultBufferBytes        = 5000
 	defaultHeartbeatTime      = false
 	defaultHeartbeatTimeBytes = 8
+	defaultCompressThreshold  = 1024
+)
+
+const (
+	defaultExtensionSizeBytes      = 2
+	defaultExtensionKeySizeBytes   = 1
+	defaultExtensionValueSizeBytes = 2
+	defaultBatchCountBytes         = 2
 )
 
 const (
@@ -37,6 +66,8 @@ const (
 	defaultSeqBytesKey      = "etc.packet.seqBytes"
 	defaultBufferBytesKey   = "etc.packet.bufferBytes"
 	defaultHeartbeatTimeKey = "etc.packet.heartbeatTime"
+	defaultCompressorKey    = "etc.packet.compressor"
+	defaultCompressThresKey = "etc.packet.compressThreshold"
 )
 
 type options struct {
@@ -59,6 +90,14 @@ type options struct {
 	// 是否携带心跳时间
 	// 默认为false
 	heartbeatTime bool
+
+	// 消息压缩器，为nil时不开启压缩
+	// 默认为nil
+	compressor compress.Compressor
+
+	// 压缩阈值，消息字节数达到阈值时才进行压缩
+	// 默认为1024字节
+	compressThreshold int
 }
 
 type Option func(o *options)
@@ -70,6 +109,12 @@ func defaultOptions() *options {
 		seqBytes:      etc.Get(defaultSeqBytesKey, defaultSeqBytes).Int(),
 		bufferBytes:   etc.Get(defaultBufferBytesKey, defaultBufferBytes).Int(),
 		heartbeatTime: etc.Get(defaultHeartbeatTimeKey, defaultHeartbeatTime).Bool(),
+
+		compressThreshold: etc.Get(defaultCompressThresKey, defaultCompressThreshold).Int(),
+	}
+
+	if name := etc.Get(defaultCompressorKey).String(); name != "" {
+		opts.compressor = compress.Invoke(name)
 	}
 
 	endian := etc.Get(defaultEndianKey, bigEndian).String()
@@ -107,3 +152,13 @@ func WithBufferBytes(bufferBytes int) Option {
 func WithHeartbeatTime(heartbeatTime bool) Option {
 	return func(o *options) { o.heartbeatTime = heartbeatTime }
 }
+
+// WithCompressor 设置消息压缩器
+func WithCompressor(compressor compress.Compressor) Option {
+	return func(o *options) { o.compressor = compressor }
+}
+
+// WithCompressThreshold 设置压缩阈值
+func WithCompressThreshold(compressThreshold int) Option {
+	return func(o *options) { o.compressThreshold = compressThreshold }
+}
@@ -4,17 +4,24 @@ import (
 	"bytes"
 	"encoding/binary"
 	"io"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/dobyte/due/v2/compress"
 	"github.com/dobyte/due/v2/core/buffer"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/log"
 )
 
 const (
-	dataBit      = 0 << 7 // 数据标识
-	heartbeatBit = 1 << 7 // 心跳标识
+	dataBit        = 0 << 7 // 数据标识
+	heartbeatBit   = 1 << 7 // 心跳标识
+	compressBit    = 1 << 6 // 压缩标识
+	extensionBit   = 1 << 5 // 扩展头标识
+	batchBit       = 1 << 4 // 批量标识
+	precompressBit = 1 << 3 // 预压缩标识，消息内容已由调用方压缩后再加密
 )
 
 type NocopyReader interface {
@@ -46,6 +53,37 @@ type Packer interface {
 	CheckHeartbeat(data []byte) (bool, error)
 }
 
+// BatchPacker 支持批量数据包的打包器
+// 自定义打包器未实现此接口时，网关不会合并推送的消息
+type BatchPacker interface {
+	// PackBatch 打包批量消息
+	PackBatch(messages ...*Message) ([]byte, error)
+	// MergeBatch 将多个已打包的数据包合并为批量数据包
+	MergeBatch(packets ...[]byte) ([]byte, error)
+	// UnpackBatch 解包批量消息，返回批量数据包中的所有数据包
+	UnpackBatch(data []byte) ([][]byte, error)
+	// CheckBatch 检测是否为批量数据包
+	CheckBatch(data []byte) (bool, error)
+}
+
+// BufferCompressor 支持压缩消息内容的打包器
+// 消息内容需要加密时，应先通过此接口压缩后再加密，并设置Message.Compressed标识
+type BufferCompressor interface {
+	// CompressBuffer 压缩消息内容，返回压缩后的内容以及是否进行了压缩
+	CompressBuffer(buffer []byte) ([]byte, bool, error)
+	// DecompressBuffer 解压缩消息内容
+	DecompressBuffer(buffer []byte) ([]byte, error)
+}
+
+// RouteExtractor 支持仅解析路由号的打包器
+// 用于在不解压缩消息内容的情况下快速获取数据包的路由号，如慢消费者策略判断关键路由
+type RouteExtractor interface {
+	// ExtractRoute 提取数据包的路由号
+	ExtractRoute(data []byte) (int32, error)
+	// ExtractSeqRoute 提取数据包的序列号与路由号
+	ExtractSeqRoute(data []byte) (seq int32, route int32, err error)
+}
+
 type defaultPacker struct {
 	opts             *options
 	heartbeat        []byte
@@ -188,23 +226,37 @@ func (p *defaultPacker) PackMessage(message *Message) ([]byte, error) {
 		}
 	}
 
-	if len(message.Buffer) > p.opts.bufferBytes {
+	header, buffer, err := p.compress(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buffer) > p.opts.bufferBytes {
 		return nil, errors.ErrMessageTooLarge
 	}
 
+	extensions, err := p.packExtensions(message.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extensions) > 0 {
+		header |= extensionBit
+	}
+
 	var (
-		size = defaultHeaderBytes + p.opts.routeBytes + p.opts.seqBytes + len(message.Buffer)
+		size = defaultHeaderBytes + p.opts.routeBytes + p.opts.seqBytes + len(extensions) + len(buffer)
 		buf  = &bytes.Buffer{}
 	)
 
 	buf.Grow(size + defaultSizeBytes)
 
-	err := binary.Write(buf, p.opts.byteOrder, int32(size))
+	err = binary.Write(buf, p.opts.byteOrder, int32(size))
 	if err != nil {
 		return nil, err
 	}
 
-	err = binary.Write(buf, p.opts.byteOrder, int8(dataBit))
+	err = binary.Write(buf, p.opts.byteOrder, header)
 	if err != nil {
 		return nil, err
 	}
@@ -233,7 +285,11 @@ func (p *defaultPacker) PackMessage(message *Message) ([]byte, error) {
 		return nil, err
 	}
 
-	err = binary.Write(buf, p.opts.byteOrder, message.Buffer)
+	if len(extensions) > 0 {
+		buf.Write(extensions)
+	}
+
+	err = binary.Write(buf, p.opts.byteOrder, buffer)
 	if err != nil {
 		return nil, err
 	}
@@ -253,18 +309,32 @@ func (p *defaultPacker) PackBuffer(message *Message) (buffer.Buffer, error) {
 		}
 	}
 
-	if len(message.Buffer) > p.opts.bufferBytes {
+	header, data, err := p.compress(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > p.opts.bufferBytes {
 		return nil, errors.ErrMessageTooLarge
 	}
 
+	extensions, err := p.packExtensions(message.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extensions) > 0 {
+		header |= extensionBit
+	}
+
 	var (
-		size = defaultHeaderBytes + p.opts.routeBytes + p.opts.seqBytes + len(message.Buffer)
+		size = defaultHeaderBytes + p.opts.routeBytes + p.opts.seqBytes + len(extensions) + len(data)
 		buf  = buffer.NewNocopyBuffer()
 	)
 
-	writer := buf.Malloc(defaultSizeBytes + defaultHeaderBytes + p.opts.routeBytes + p.opts.seqBytes)
+	writer := buf.Malloc(defaultSizeBytes + defaultHeaderBytes + p.opts.routeBytes + p.opts.seqBytes + len(extensions))
 	writer.WriteInt32s(p.opts.byteOrder, int32(size))
-	writer.WriteInt8s(int8(dataBit))
+	writer.WriteUint8s(header)
 
 	switch p.opts.routeBytes {
 	case 1:
@@ -284,7 +354,11 @@ func (p *defaultPacker) PackBuffer(message *Message) (buffer.Buffer, error) {
 		writer.WriteInt32s(p.opts.byteOrder, message.Seq)
 	}
 
-	buf.Mount(message.Buffer)
+	if len(extensions) > 0 {
+		writer.WriteBytes(extensions...)
+	}
+
+	buf.Mount(data)
 
 	return buf, nil
 }
@@ -316,7 +390,7 @@ func (p *defaultPacker) UnpackMessage(data []byte) (*Message, error) {
 		return nil, err
 	}
 
-	if header&dataBit != dataBit {
+	if header&dataBit != dataBit || header&batchBit == batchBit {
 		return nil, errors.ErrInvalidMessage
 	}
 
@@ -370,11 +444,212 @@ func (p *defaultPacker) UnpackMessage(data []byte) (*Message, error) {
 		}
 	}
 
+	if header&extensionBit == extensionBit {
+		headers, n, err := p.unpackExtensions(data[ln:])
+		if err != nil {
+			return nil, err
+		}
+
+		message.Headers = headers
+		ln += n
+	}
+
 	message.Buffer = data[ln:]
 
+	if header&compressBit == compressBit {
+		if message.Buffer, err = p.DecompressBuffer(message.Buffer); err != nil {
+			return nil, err
+		}
+	}
+
+	message.Compressed = header&precompressBit == precompressBit
+
 	return message, nil
 }
 
+// ExtractRoute 提取数据包的路由号，仅读取数据包头与路由号，不解析消息内容
+func (p *defaultPacker) ExtractRoute(data []byte) (int32, error) {
+	_, route, err := p.ExtractSeqRoute(data)
+
+	return route, err
+}
+
+// ExtractSeqRoute 提取数据包的序列号与路由号，仅读取数据包头、路由号与序列号，不解析消息内容
+func (p *defaultPacker) ExtractSeqRoute(data []byte) (int32, int32, error) {
+	ln := defaultSizeBytes + defaultHeaderBytes + p.opts.routeBytes
+
+	if len(data) < ln+p.opts.seqBytes {
+		return 0, 0, errors.ErrInvalidMessage
+	}
+
+	if uint64(len(data))-defaultSizeBytes != uint64(p.opts.byteOrder.Uint32(data)) {
+		return 0, 0, errors.ErrInvalidMessage
+	}
+
+	if header := data[defaultSizeBytes]; header&heartbeatBit == heartbeatBit || header&batchBit == batchBit {
+		return 0, 0, errors.ErrInvalidMessage
+	}
+
+	var (
+		route = p.extractInt(data[defaultSizeBytes+defaultHeaderBytes:ln], p.opts.routeBytes)
+		seq   = p.extractInt(data[ln:ln+p.opts.seqBytes], p.opts.seqBytes)
+	)
+
+	return seq, route, nil
+}
+
+// 按字节数读取有符号整数
+func (p *defaultPacker) extractInt(buf []byte, n int) int32 {
+	switch n {
+	case 1:
+		return int32(int8(buf[0]))
+	case 2:
+		return int32(int16(p.opts.byteOrder.Uint16(buf)))
+	case 4:
+		return int32(p.opts.byteOrder.Uint32(buf))
+	default:
+		return 0
+	}
+}
+
+// 压缩消息，返回数据包头与消息内容
+func (p *defaultPacker) compress(message *Message) (uint8, []byte, error) {
+	if message.Compressed {
+		return dataBit | precompressBit, message.Buffer, nil
+	}
+
+	data, ok, err := p.CompressBuffer(message.Buffer)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if ok {
+		return dataBit | compressBit, data, nil
+	}
+
+	return dataBit, data, nil
+}
+
+// CompressBuffer 压缩消息内容
+// 消息内容长度达到压缩阈值且压缩后长度更短时进行压缩；消息内容超过最大字节数时返回errors.ErrMessageTooLarge
+func (p *defaultPacker) CompressBuffer(buffer []byte) ([]byte, bool, error) {
+	if len(buffer) > p.opts.bufferBytes {
+		return nil, false, errors.ErrMessageTooLarge
+	}
+
+	if p.opts.compressor == nil || len(buffer) == 0 || len(buffer) < p.opts.compressThreshold {
+		return buffer, false, nil
+	}
+
+	data, err := p.opts.compressor.Compress(buffer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(data) >= len(buffer) {
+		return buffer, false, nil
+	}
+
+	return data, true, nil
+}
+
+// DecompressBuffer 解压缩消息内容，解压缩后的内容超过最大字节数时返回errors.ErrMessageTooLarge
+func (p *defaultPacker) DecompressBuffer(buffer []byte) ([]byte, error) {
+	if p.opts.compressor == nil {
+		return nil, errors.ErrInvalidMessage
+	}
+
+	if c, ok := p.opts.compressor.(compress.LimitedDecompressor); ok {
+		return c.DecompressLimit(buffer, p.opts.bufferBytes)
+	}
+
+	data, err := p.opts.compressor.Decompress(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > p.opts.bufferBytes {
+		return nil, errors.ErrMessageTooLarge
+	}
+
+	return data, nil
+}
+
+// 打包扩展头
+func (p *defaultPacker) packExtensions(headers map[string]string) ([]byte, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(headers))
+	size := 0
+	for key, value := range headers {
+		if len(key) == 0 || len(key) > math.MaxUint8 || len(value) > math.MaxUint16 {
+			return nil, errors.ErrInvalidHeader
+		}
+
+		keys = append(keys, key)
+		size += defaultExtensionKeySizeBytes + len(key) + defaultExtensionValueSizeBytes + len(value)
+	}
+
+	if size > math.MaxUint16 {
+		return nil, errors.ErrInvalidHeader
+	}
+
+	sort.Strings(keys)
+
+	buf := make([]byte, defaultExtensionSizeBytes+size)
+	p.opts.byteOrder.PutUint16(buf, uint16(size))
+
+	n := defaultExtensionSizeBytes
+	for _, key := range keys {
+		value := headers[key]
+		buf[n] = uint8(len(key))
+		n += defaultExtensionKeySizeBytes
+		n += copy(buf[n:], key)
+		p.opts.byteOrder.PutUint16(buf[n:], uint16(len(value)))
+		n += defaultExtensionValueSizeBytes
+		n += copy(buf[n:], value)
+	}
+
+	return buf, nil
+}
+
+// 解包扩展头，返回扩展头及其占用的字节数
+func (p *defaultPacker) unpackExtensions(data []byte) (map[string]string, int, error) {
+	if len(data) < defaultExtensionSizeBytes {
+		return nil, 0, errors.ErrInvalidMessage
+	}
+
+	size := int(p.opts.byteOrder.Uint16(data))
+	data = data[defaultExtensionSizeBytes:]
+
+	if len(data) < size {
+		return nil, 0, errors.ErrInvalidMessage
+	}
+
+	headers := make(map[string]string)
+	for data = data[:size]; len(data) > 0; {
+		n := int(data[0])
+		if len(data) < defaultExtensionKeySizeBytes+n+defaultExtensionValueSizeBytes {
+			return nil, 0, errors.ErrInvalidMessage
+		}
+
+		key := string(data[defaultExtensionKeySizeBytes : defaultExtensionKeySizeBytes+n])
+		data = data[defaultExtensionKeySizeBytes+n:]
+
+		m := int(p.opts.byteOrder.Uint16(data))
+		if len(data) < defaultExtensionValueSizeBytes+m {
+			return nil, 0, errors.ErrInvalidMessage
+		}
+
+		headers[key] = string(data[defaultExtensionValueSizeBytes : defaultExtensionValueSizeBytes+m])
+		data = data[defaultExtensionValueSizeBytes+m:]
+	}
+
+	return headers, defaultExtensionSizeBytes + size, nil
+}
+
 // PackHeartbeat 打包心跳
 func (p *defaultPacker) PackHeartbeat() ([]byte, error) {
 	if !p.opts.heartbeatTime {
@@ -434,3 +709,96 @@ func (p *defaultPacker) CheckHeartbeat(data []byte) (bool, error) {
 
 	return header&heartbeatBit == heartbeatBit, nil
 }
+
+// PackBatch 打包批量消息
+func (p *defaultPacker) PackBatch(messages ...*Message) ([]byte, error) {
+	packets := make([][]byte, 0, len(messages))
+
+	for _, message := range messages {
+		packet, err := p.PackMessage(message)
+		if err != nil {
+			return nil, err
+		}
+
+		packets = append(packets, packet)
+	}
+
+	return p.MergeBatch(packets...)
+}
+
+// MergeBatch 将多个已打包的数据包合并为批量数据包
+func (p *defaultPacker) MergeBatch(packets ...[]byte) ([]byte, error) {
+	if len(packets) == 0 || len(packets) > math.MaxUint16 {
+		return nil, errors.ErrInvalidArgument
+	}
+
+	size := defaultHeaderBytes + defaultBatchCountBytes
+	for _, packet := range packets {
+		size += len(packet)
+	}
+
+	if size > math.MaxInt32 {
+		return nil, errors.ErrMessageTooLarge
+	}
+
+	buf := make([]byte, defaultSizeBytes+size)
+	p.opts.byteOrder.PutUint32(buf, uint32(size))
+	buf[defaultSizeBytes] = batchBit
+	p.opts.byteOrder.PutUint16(buf[defaultSizeBytes+defaultHeaderBytes:], uint16(len(packets)))
+
+	n := defaultSizeBytes + defaultHeaderBytes + defaultBatchCountBytes
+	for _, packet := range packets {
+		n += copy(buf[n:], packet)
+	}
+
+	return buf, nil
+}
+
+// UnpackBatch 解包批量消息，返回批量数据包中的所有数据包
+func (p *defaultPacker) UnpackBatch(data []byte) ([][]byte, error) {
+	ok, err := p.CheckBatch(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || len(data) < defaultSizeBytes+defaultHeaderBytes+defaultBatchCountBytes {
+		return nil, errors.ErrInvalidMessage
+	}
+
+	count := int(p.opts.byteOrder.Uint16(data[defaultSizeBytes+defaultHeaderBytes:]))
+	data = data[defaultSizeBytes+defaultHeaderBytes+defaultBatchCountBytes:]
+	packets := make([][]byte, 0, count)
+
+	for i := 0; i < count; i++ {
+		if len(data) < defaultSizeBytes {
+			return nil, errors.ErrInvalidMessage
+		}
+
+		size := uint64(p.opts.byteOrder.Uint32(data)) + defaultSizeBytes
+		if uint64(len(data)) < size {
+			return nil, errors.ErrInvalidMessage
+		}
+
+		packets = append(packets, data[:size])
+		data = data[size:]
+	}
+
+	if len(data) != 0 {
+		return nil, errors.ErrInvalidMessage
+	}
+
+	return packets, nil
+}
+
+// CheckBatch 检测是否为批量数据包
+func (p *defaultPacker) CheckBatch(data []byte) (bool, error) {
+	if len(data) < defaultSizeBytes+defaultHeaderBytes {
+		return false, errors.ErrInvalidMessage
+	}
+
+	if uint64(len(data))-defaultSizeBytes != uint64(p.opts.byteOrder.Uint32(data)) {
+		return false, errors.ErrInvalidMessage
+	}
+
+	return data[defaultSizeBytes]&batchBit == batchBit, nil
+}
@@ -1,6 +1,9 @@
 package packet
 
-import "github.com/dobyte/due/v2/core/buffer"
+import (
+	"github.com/dobyte/due/v2/core/buffer"
+	"github.com/dobyte/due/v2/errors"
+)
 
 var globalPacker Packer
 
@@ -38,6 +41,94 @@ func UnpackMessage(data []byte) (*Message, error) {
 	return globalPacker.UnpackMessage(data)
 }
 
+// ExtractRoute 提取数据包的路由号，打包器未实现RouteExtractor接口时通过解包消息获取
+func ExtractRoute(data []byte) (int32, error) {
+	if p, ok := globalPacker.(RouteExtractor); ok {
+		return p.ExtractRoute(data)
+	}
+
+	message, err := globalPacker.UnpackMessage(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return message.Route, nil
+}
+
+// ExtractSeqRoute 提取数据包的序列号与路由号，打包器未实现RouteExtractor接口时通过解包消息获取
+func ExtractSeqRoute(data []byte) (int32, int32, error) {
+	if p, ok := globalPacker.(RouteExtractor); ok {
+		return p.ExtractSeqRoute(data)
+	}
+
+	message, err := globalPacker.UnpackMessage(data)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return message.Seq, message.Route, nil
+}
+
+// IsBatchSupported 检测打包器是否支持批量数据包
+func IsBatchSupported() bool {
+	_, ok := globalPacker.(BatchPacker)
+	return ok
+}
+
+// PackBatch 打包批量消息，打包器不支持批量数据包时返回errors.ErrIllegalOperation
+func PackBatch(messages ...*Message) ([]byte, error) {
+	if p, ok := globalPacker.(BatchPacker); ok {
+		return p.PackBatch(messages...)
+	}
+
+	return nil, errors.ErrIllegalOperation
+}
+
+// MergeBatch 将多个已打包的数据包合并为批量数据包，打包器不支持批量数据包时返回errors.ErrIllegalOperation
+func MergeBatch(packets ...[]byte) ([]byte, error) {
+	if p, ok := globalPacker.(BatchPacker); ok {
+		return p.MergeBatch(packets...)
+	}
+
+	return nil, errors.ErrIllegalOperation
+}
+
+// UnpackBatch 解包批量消息，返回批量数据包中的所有数据包，打包器不支持批量数据包时返回errors.ErrIllegalOperation
+func UnpackBatch(data []byte) ([][]byte, error) {
+	if p, ok := globalPacker.(BatchPacker); ok {
+		return p.UnpackBatch(data)
+	}
+
+	return nil, errors.ErrIllegalOperation
+}
+
+// CheckBatch 检测是否为批量数据包，打包器不支持批量数据包时始终返回false
+func CheckBatch(data []byte) (bool, error) {
+	if p, ok := globalPacker.(BatchPacker); ok {
+		return p.CheckBatch(data)
+	}
+
+	return false, nil
+}
+
+// CompressBuffer 压缩消息内容，打包器不支持压缩消息内容时返回原内容
+func CompressBuffer(buffer []byte) ([]byte, bool, error) {
+	if p, ok := globalPacker.(BufferCompressor); ok {
+		return p.CompressBuffer(buffer)
+	}
+
+	return buffer, false, nil
+}
+
+// DecompressBuffer 解压缩消息内容，打包器不支持压缩消息内容时返回errors.ErrInvalidMessage
+func DecompressBuffer(buffer []byte) ([]byte, error) {
+	if p, ok := globalPacker.(BufferCompressor); ok {
+		return p.DecompressBuffer(buffer)
+	}
+
+	return nil, errors.ErrInvalidMessage
+}
+
 // PackHeartbeat 打包心跳
 func PackHeartbeat() ([]byte, error) {
 	return globalPacker.PackHeartbeat()
@@ -2,6 +2,9 @@ package packet_test
 
 import (
 	"bytes"
+	"crypto/rand"
+	"github.com/dobyte/due/v2/compress/gzip"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/packet"
 	"github.com/dobyte/due/v2/utils/xrand"
 	"testing"
@@ -33,6 +36,221 @@ func TestDefaultPacker_PackMessage(t *testing.T) {
 	t.Logf("buffer: %s", string(message.Buffer))
 }
 
+func TestDefaultPacker_Compress(t *testing.T) {
+	compressor := packet.NewPacker(
+		packet.WithCompressor(gzip.DefaultCompressor),
+		packet.WithCompressThreshold(64),
+	)
+
+	for _, buffer := range [][]byte{
+		[]byte("hello world"),
+		bytes.Repeat([]byte("hello world"), 100),
+	} {
+		data, err := compressor.PackMessage(&packet.Message{
+			Seq:    1,
+			Route:  1,
+			Buffer: buffer,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Logf("raw: %d packed: %d", len(buffer), len(data))
+
+		message, err := compressor.UnpackMessage(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(message.Buffer, buffer) {
+			t.Fatalf("buffer mismatch, raw: %d unpacked: %d", len(buffer), len(message.Buffer))
+		}
+	}
+}
+
+func TestDefaultPacker_DecompressLimit(t *testing.T) {
+	sender := packet.NewPacker(
+		packet.WithCompressor(gzip.DefaultCompressor),
+		packet.WithCompressThreshold(64),
+		packet.WithBufferBytes(1<<20),
+	)
+
+	receiver := packet.NewPacker(
+		packet.WithCompressor(gzip.DefaultCompressor),
+		packet.WithBufferBytes(4096),
+	)
+
+	// 压缩后的数据包远小于消息内容的最大字节数，解压缩后远超最大字节数
+	data, err := sender.PackMessage(&packet.Message{
+		Seq:    1,
+		Route:  1,
+		Buffer: bytes.Repeat([]byte{0}, 1<<20),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data) > 4096 {
+		t.Fatalf("packed size is too large: %d", len(data))
+	}
+
+	if _, err = receiver.UnpackMessage(data); !errors.Is(err, errors.ErrMessageTooLarge) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = receiver.PackMessage(&packet.Message{Route: 1, Buffer: make([]byte, 4097)}); !errors.Is(err, errors.ErrMessageTooLarge) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDefaultPacker_Precompress(t *testing.T) {
+	compressor := packet.NewPacker(
+		packet.WithCompressor(gzip.DefaultCompressor),
+		packet.WithCompressThreshold(64),
+	)
+
+	raw := bytes.Repeat([]byte("hello world"), 100)
+
+	buffer, ok, err := compressor.CompressBuffer(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok || len(buffer) >= len(raw) {
+		t.Fatal("buffer is not compressed")
+	}
+
+	data, err := compressor.PackMessage(&packet.Message{
+		Seq:        1,
+		Route:      1,
+		Buffer:     buffer,
+		Compressed: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := compressor.UnpackMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !message.Compressed || !bytes.Equal(message.Buffer, buffer) {
+		t.Fatal("precompressed buffer is modified")
+	}
+
+	buffer, err = compressor.DecompressBuffer(message.Buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buffer, raw) {
+		t.Fatal("decompressed buffer mismatch")
+	}
+
+	// 压缩后长度未减小时不进行压缩
+	random := make([]byte, 128)
+	_, _ = rand.Read(random)
+
+	if _, ok, err = compressor.CompressBuffer(random); err != nil || ok {
+		t.Fatalf("random buffer should not be compressed, ok: %v err: %v", ok, err)
+	}
+}
+
+func TestDefaultPacker_Headers(t *testing.T) {
+	headers := map[string]string{
+		"trace-id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"version":  "1.0.2",
+		"locale":   "zh-CN",
+	}
+
+	data, err := packer.PackMessage(&packet.Message{
+		Seq:     1,
+		Route:   1,
+		Buffer:  []byte("hello world"),
+		Headers: headers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := packer.UnpackMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(message.Buffer) != "hello world" {
+		t.Fatalf("buffer mismatch: %s", string(message.Buffer))
+	}
+
+	for key, value := range headers {
+		if message.Headers[key] != value {
+			t.Fatalf("header %s mismatch, want: %s got: %s", key, value, message.Headers[key])
+		}
+	}
+
+	buf, err := packer.PackBuffer(&packet.Message{
+		Seq:     1,
+		Route:   1,
+		Buffer:  []byte("hello world"),
+		Headers: headers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Release()
+
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("pack buffer mismatch")
+	}
+}
+
+func TestDefaultPacker_Batch(t *testing.T) {
+	messages := []*packet.Message{
+		{Seq: 1, Route: 1, Buffer: []byte("hello")},
+		{Seq: 2, Route: 2, Buffer: []byte("world"), Headers: map[string]string{"trace-id": "1"}},
+		{Seq: 3, Route: 3},
+	}
+
+	data, err := packer.PackBatch(messages...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := packer.CheckBatch(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Fatal("not a batch packet")
+	}
+
+	if _, err = packer.UnpackMessage(data); err == nil {
+		t.Fatal("unpack batch packet as message")
+	}
+
+	packets, err := packer.UnpackBatch(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(packets) != len(messages) {
+		t.Fatalf("packets count mismatch, want: %d got: %d", len(messages), len(packets))
+	}
+
+	for i, buf := range packets {
+		message, err := packer.UnpackMessage(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if message.Seq != messages[i].Seq || message.Route != messages[i].Route || !bytes.Equal(message.Buffer, messages[i].Buffer) {
+			t.Fatalf("message %d mismatch", i)
+		}
+	}
+}
+
 func TestPackHeartbeat(t *testing.T) {
 	data, err := packer.PackHeartbeat()
 	if err != nil {
@@ -128,3 +346,42 @@ func BenchmarkDefaultPacker_UnpackMessage(b *testing.B) {
 		}
 	}
 }
+
+func TestExtractRoute(t *testing.T) {
+	msg, err := packet.PackMessage(&packet.Message{
+		Seq:     1,
+		Route:   -2,
+		Headers: map[string]string{"trace": "1"},
+		Buffer:  bytes.Repeat([]byte("a"), 4096),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	route, err := packet.ExtractRoute(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if route != -2 {
+		t.Fatalf("route mismatch, want: -2 got: %d", route)
+	}
+
+	seq, route, err := packet.ExtractSeqRoute(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seq != 1 || route != -2 {
+		t.Fatalf("seq or route mismatch, want: 1/-2 got: %d/%d", seq, route)
+	}
+
+	heartbeat, err := packet.PackHeartbeat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = packet.ExtractRoute(heartbeat); err == nil {
+		t.Fatal("extract route from heartbeat should fail")
+	}
+}
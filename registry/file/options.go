@@ -0,0 +1,69 @@
+package file
+
+import (
+	"context"
+	"time"
+
+	"github.com/dobyte/due/v2/etc"
+)
+
+const (
+	defaultDir      = "./run/registry"
+	defaultInterval = "1s"
+	defaultTTL      = "5s"
+)
+
+const (
+	defaultDirKey      = "etc.registry.file.dir"
+	defaultIntervalKey = "etc.registry.file.interval"
+	defaultTTLKey      = "etc.registry.file.ttl"
+)
+
+type Option func(o *options)
+
+type options struct {
+	// 上下文
+	// 默认为context.Background
+	ctx context.Context
+
+	// 服务实例文件存储目录，同一集群内的所有进程需使用相同的目录
+	// 默认为./run/registry
+	dir string
+
+	// 心跳及监听轮询的时间间隔
+	// 默认为1s
+	interval time.Duration
+
+	// 服务实例过期时间，超过过期时间未更新心跳的服务实例将被视为已下线
+	// 默认为5s
+	ttl time.Duration
+}
+
+func defaultOptions() *options {
+	return &options{
+		ctx:      context.Background(),
+		dir:      etc.Get(defaultDirKey, defaultDir).String(),
+		interval: etc.Get(defaultIntervalKey, defaultInterval).Duration(),
+		ttl:      etc.Get(defaultTTLKey, defaultTTL).Duration(),
+	}
+}
+
+// WithContext 设置上下文
+func WithContext(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// WithDir 设置服务实例文件存储目录
+func WithDir(dir string) Option {
+	return func(o *options) { o.dir = dir }
+}
+
+// WithInterval 设置心跳及监听轮询的时间间隔
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) { o.interval = interval }
+}
+
+// WithTTL 设置服务实例过期时间
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) { o.ttl = ttl }
+}
@@ -0,0 +1,269 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/registry"
+)
+
+const name = "file"
+
+const ext = ".json"
+
+var _ registry.Registry = &Registry{}
+
+// Registry 基于本地文件的服务注册发现组件
+// 每个服务实例以独立的文件存储于共享目录中，并定时刷新文件修改时间作为心跳
+// 适用于同一台机器上的多个进程在无外部依赖的情况下相互发现
+type Registry struct {
+	opts      *options
+	ctx       context.Context
+	cancel    context.CancelFunc
+	mu        sync.Mutex // 实例文件写入锁，避免心跳与解注册并发时重新写入已解注册的实例文件
+	rw        sync.RWMutex
+	instances map[string]*registry.ServiceInstance
+	watchers  sync.Map
+}
+
+func NewRegistry(opts ...Option) *Registry {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &Registry{}
+	r.opts = o
+	r.ctx, r.cancel = context.WithCancel(o.ctx)
+	r.instances = make(map[string]*registry.ServiceInstance)
+
+	go r.heartbeat()
+
+	return r
+}
+
+// Name 获取服务注册发现组件名
+func (r *Registry) Name() string {
+	return name
+}
+
+// Register 注册服务实例
+func (r *Registry) Register(ctx context.Context, ins *registry.ServiceInstance) error {
+	clone := *ins
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(&clone); err != nil {
+		return err
+	}
+
+	r.rw.Lock()
+	r.instances[makeInsID(ins)] = &clone
+	r.rw.Unlock()
+
+	return nil
+}
+
+// Deregister 解注册服务实例
+func (r *Registry) Deregister(ctx context.Context, ins *registry.ServiceInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rw.Lock()
+	delete(r.instances, makeInsID(ins))
+	r.rw.Unlock()
+
+	if err := os.Remove(r.makePath(ins)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Watch 监听相同服务名的服务实例变化
+func (r *Registry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	if v, ok := r.watchers.Load(serviceName); ok {
+		return v.(*watcherMgr).fork(), nil
+	}
+
+	services, err := r.services(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	wm := newWatcherMgr(r, serviceName, services)
+
+	if v, loaded := r.watchers.LoadOrStore(serviceName, wm); loaded {
+		wm.cancel()
+		return v.(*watcherMgr).fork(), nil
+	}
+
+	go wm.watch()
+
+	return wm.fork(), nil
+}
+
+// Services 获取服务实例列表
+func (r *Registry) Services(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	if v, ok := r.watchers.Load(serviceName); ok {
+		return v.(*watcherMgr).services(), nil
+	}
+
+	return r.services(serviceName)
+}
+
+// Close 关闭服务注册发现组件
+func (r *Registry) Close() error {
+	r.cancel()
+
+	return nil
+}
+
+// 读取服务实例列表，忽略已过期的服务实例
+func (r *Registry) services(serviceName string) ([]*registry.ServiceInstance, error) {
+	entries, err := os.ReadDir(filepath.Join(r.opts.dir, serviceName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	services := make([]*registry.ServiceInstance, 0, len(entries))
+	deadline := time.Now().Add(-r.opts.ttl)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ext) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || info.ModTime().Before(deadline) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(r.opts.dir, serviceName, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		ins := &registry.ServiceInstance{}
+
+		if err = json.Unmarshal(data, ins); err != nil {
+			continue
+		}
+
+		services = append(services, ins)
+	}
+
+	return services, nil
+}
+
+// 写入服务实例文件，先写入临时文件再重命名以避免读取到不完整的内容
+func (r *Registry) write(ins *registry.ServiceInstance) error {
+	if ins.Name == "" {
+		return errors.ErrInvalidArgument
+	}
+
+	data, err := json.Marshal(ins)
+	if err != nil {
+		return err
+	}
+
+	path := r.makePath(ins)
+
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	// 每次写入使用独立的临时文件，避免并发写入同一临时文件
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Chmod(0644); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// 定时刷新已注册服务实例的文件修改时间，文件被删除时重新写入
+func (r *Registry) heartbeat() {
+	ticker := time.NewTicker(r.opts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.rw.RLock()
+			instances := make([]*registry.ServiceInstance, 0, len(r.instances))
+			for _, ins := range r.instances {
+				instances = append(instances, ins)
+			}
+			r.rw.RUnlock()
+
+			for _, ins := range instances {
+				r.keepalive(ins)
+			}
+		}
+	}
+}
+
+// 刷新服务实例文件的修改时间，文件被删除时重新写入
+// 刷新前重新检测实例是否仍处于注册状态，避免重新写入已解注册的实例文件
+func (r *Registry) keepalive(ins *registry.ServiceInstance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rw.RLock()
+	registered := r.instances[makeInsID(ins)] == ins
+	r.rw.RUnlock()
+
+	if !registered {
+		return
+	}
+
+	now := time.Now()
+
+	if err := os.Chtimes(r.makePath(ins), now, now); err == nil {
+		return
+	}
+
+	if err := r.write(ins); err != nil {
+		log.Warnf("service instance heartbeat failed, id: %s name: %s err: %v", ins.ID, ins.Name, err)
+	}
+}
+
+// 构建服务实例文件路径
+func (r *Registry) makePath(ins *registry.ServiceInstance) string {
+	return filepath.Join(r.opts.dir, ins.Name, makeInsID(ins)+ext)
+}
+
+// 构建实例ID
+func makeInsID(ins *registry.ServiceInstance) string {
+	return ins.Kind + "-" + ins.ID
+}
@@ -0,0 +1,110 @@
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/registry"
+	"github.com/dobyte/due/v2/registry/file"
+)
+
+func TestRegistry_Watch(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		dir  = t.TempDir()
+		reg1 = file.NewRegistry(file.WithDir(dir), file.WithInterval(20*time.Millisecond))
+		reg2 = file.NewRegistry(file.WithDir(dir), file.WithInterval(20*time.Millisecond))
+		ins  = &registry.ServiceInstance{
+			ID:    "test-1",
+			Name:  cluster.Node.String(),
+			Kind:  cluster.Node.String(),
+			State: cluster.Work.String(),
+		}
+	)
+	defer reg1.Close()
+	defer reg2.Close()
+
+	watcher, err := reg2.Watch(ctx, cluster.Node.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	services, err := watcher.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 0 {
+		t.Fatalf("unexpected services: %v", services)
+	}
+
+	if err = reg1.Register(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].ID != ins.ID {
+		t.Fatalf("unexpected services: %v", services)
+	}
+
+	if err = reg1.Deregister(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 0 {
+		t.Fatalf("unexpected services: %v", services)
+	}
+}
+
+func TestRegistry_Deregister(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+		reg = file.NewRegistry(file.WithDir(dir), file.WithInterval(time.Millisecond))
+	)
+	defer reg.Close()
+
+	for i := 0; i < 20; i++ {
+		ins := &registry.ServiceInstance{
+			ID:    "test-" + strconv.Itoa(i),
+			Name:  cluster.Node.String(),
+			Kind:  cluster.Node.String(),
+			State: cluster.Work.String(),
+		}
+
+		if err := reg.Register(ctx, ins); err != nil {
+			t.Fatal(err)
+		}
+
+		time.Sleep(time.Millisecond)
+
+		if err := reg.Deregister(ctx, ins); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 等待心跳执行，已解注册的实例文件不应被重新写入
+	time.Sleep(20 * time.Millisecond)
+
+	entries, err := os.ReadDir(filepath.Join(dir, cluster.Node.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("unexpected files after deregister: %v", entries)
+	}
+}
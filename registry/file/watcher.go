@@ -0,0 +1,169 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dobyte/due/v2/registry"
+)
+
+type watcherMgr struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	registry    *Registry
+	serviceName string
+	idx         atomic.Int64
+	rw          sync.RWMutex
+	list        []*registry.ServiceInstance
+	fingerprint []byte
+	watchers    map[int64]*watcher
+}
+
+type watcher struct {
+	idx        int64
+	state      atomic.Bool
+	watcherMgr *watcherMgr
+	ctx        context.Context
+	cancel     context.CancelFunc
+	chWatch    chan []*registry.ServiceInstance
+}
+
+func newWatcher(wm *watcherMgr, idx int64) *watcher {
+	w := &watcher{}
+	w.ctx, w.cancel = context.WithCancel(wm.ctx)
+	w.idx = idx
+	w.watcherMgr = wm
+	w.chWatch = make(chan []*registry.ServiceInstance, 1)
+
+	return w
+}
+
+// 通知服务实例变化，未被读取的旧服务实例列表将被最新的列表替换
+func (w *watcher) notify(services []*registry.ServiceInstance) {
+	for {
+		select {
+		case w.chWatch <- services:
+			return
+		default:
+			select {
+			case <-w.chWatch:
+			default:
+			}
+		}
+	}
+}
+
+// Next 返回服务实例列表
+func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
+	if w.state.CompareAndSwap(false, true) {
+		return w.watcherMgr.services(), nil
+	}
+
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case services := <-w.chWatch:
+		return services, nil
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	return w.watcherMgr.recycle(w.idx)
+}
+
+func newWatcherMgr(r *Registry, serviceName string, services []*registry.ServiceInstance) *watcherMgr {
+	wm := &watcherMgr{}
+	wm.ctx, wm.cancel = context.WithCancel(r.ctx)
+	wm.registry = r
+	wm.serviceName = serviceName
+	wm.watchers = make(map[int64]*watcher)
+	wm.list = services
+	wm.fingerprint = makeFingerprint(services)
+
+	return wm
+}
+
+// 轮询服务实例目录，服务实例发生变化时通知所有监听器
+func (wm *watcherMgr) watch() {
+	ticker := time.NewTicker(wm.registry.opts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wm.ctx.Done():
+			return
+		case <-ticker.C:
+			services, err := wm.registry.services(wm.serviceName)
+			if err != nil {
+				continue
+			}
+
+			fingerprint := makeFingerprint(services)
+
+			wm.rw.Lock()
+			if bytes.Equal(fingerprint, wm.fingerprint) {
+				wm.rw.Unlock()
+				continue
+			}
+
+			wm.list = services
+			wm.fingerprint = fingerprint
+
+			for _, w := range wm.watchers {
+				w.notify(services)
+			}
+			wm.rw.Unlock()
+		}
+	}
+}
+
+func (wm *watcherMgr) fork() registry.Watcher {
+	wm.rw.Lock()
+	defer wm.rw.Unlock()
+
+	w := newWatcher(wm, wm.idx.Add(1))
+	wm.watchers[w.idx] = w
+
+	return w
+}
+
+func (wm *watcherMgr) recycle(idx int64) error {
+	wm.rw.Lock()
+	defer wm.rw.Unlock()
+
+	delete(wm.watchers, idx)
+
+	if len(wm.watchers) == 0 {
+		wm.cancel()
+		wm.registry.watchers.CompareAndDelete(wm.serviceName, wm)
+	}
+
+	return nil
+}
+
+func (wm *watcherMgr) services() []*registry.ServiceInstance {
+	wm.rw.RLock()
+	defer wm.rw.RUnlock()
+
+	return wm.list
+}
+
+// 构建服务实例列表指纹，用于判断服务实例是否发生变化
+func makeFingerprint(services []*registry.ServiceInstance) []byte {
+	list := slices.Clone(services)
+	slices.SortFunc(list, func(a, b *registry.ServiceInstance) int {
+		return strings.Compare(makeInsID(a), makeInsID(b))
+	})
+
+	data, _ := json.Marshal(list)
+
+	return data
+}
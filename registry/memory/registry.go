@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dobyte/due/v2/registry"
+)
+
+const name = "memory"
+
+var _ registry.Registry = &Registry{}
+
+// Registry 基于内存的服务注册发现组件
+// 仅在当前进程内生效，适用于在同一进程中运行整个集群的本地开发及集成测试场景
+// 同一集群内的所有组件需共用同一个注册中心实例
+type Registry struct {
+	rw       sync.RWMutex
+	services map[string]map[string]*registry.ServiceInstance // 服务名 -> 实例ID -> 服务实例
+	watchers map[string]map[*watcher]struct{}                // 服务名 -> 监听器
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		services: make(map[string]map[string]*registry.ServiceInstance),
+		watchers: make(map[string]map[*watcher]struct{}),
+	}
+}
+
+// Name 获取服务注册发现组件名
+func (r *Registry) Name() string {
+	return name
+}
+
+// Register 注册服务实例
+func (r *Registry) Register(ctx context.Context, ins *registry.ServiceInstance) error {
+	clone := *ins
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	instances, ok := r.services[ins.Name]
+	if !ok {
+		instances = make(map[string]*registry.ServiceInstance)
+		r.services[ins.Name] = instances
+	}
+
+	instances[makeInsID(ins)] = &clone
+
+	r.broadcast(ins.Name)
+
+	return nil
+}
+
+// Deregister 解注册服务实例
+func (r *Registry) Deregister(ctx context.Context, ins *registry.ServiceInstance) error {
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	instances, ok := r.services[ins.Name]
+	if !ok {
+		return nil
+	}
+
+	insID := makeInsID(ins)
+
+	if _, ok = instances[insID]; !ok {
+		return nil
+	}
+
+	delete(instances, insID)
+
+	if len(instances) == 0 {
+		delete(r.services, ins.Name)
+	}
+
+	r.broadcast(ins.Name)
+
+	return nil
+}
+
+// Watch 监听相同服务名的服务实例变化
+func (r *Registry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	w := newWatcher(r, serviceName)
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	watchers, ok := r.watchers[serviceName]
+	if !ok {
+		watchers = make(map[*watcher]struct{})
+		r.watchers[serviceName] = watchers
+	}
+
+	watchers[w] = struct{}{}
+
+	return w, nil
+}
+
+// Services 获取服务实例列表
+func (r *Registry) Services(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	r.rw.RLock()
+	defer r.rw.RUnlock()
+
+	return r.doServices(serviceName), nil
+}
+
+// 获取服务实例列表，调用方需持有锁
+func (r *Registry) doServices(serviceName string) []*registry.ServiceInstance {
+	instances := r.services[serviceName]
+	services := make([]*registry.ServiceInstance, 0, len(instances))
+
+	for _, ins := range instances {
+		services = append(services, ins)
+	}
+
+	return services
+}
+
+// 通知监听器服务实例变化，调用方需持有写锁
+func (r *Registry) broadcast(serviceName string) {
+	watchers, ok := r.watchers[serviceName]
+	if !ok || len(watchers) == 0 {
+		return
+	}
+
+	services := r.doServices(serviceName)
+
+	for w := range watchers {
+		w.notify(services)
+	}
+}
+
+// 移除监听器
+func (r *Registry) recycle(w *watcher) {
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	if watchers, ok := r.watchers[w.serviceName]; ok {
+		delete(watchers, w)
+
+		if len(watchers) == 0 {
+			delete(r.watchers, w.serviceName)
+		}
+	}
+}
+
+// 构建实例ID
+func makeInsID(ins *registry.ServiceInstance) string {
+	return ins.Kind + "-" + ins.ID
+}
@@ -0,0 +1,66 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/registry"
+	"github.com/dobyte/due/v2/registry/memory"
+)
+
+func TestRegistry_Watch(t *testing.T) {
+	ctx := context.Background()
+	reg := memory.NewRegistry()
+	ins := &registry.ServiceInstance{
+		ID:    "test-1",
+		Name:  cluster.Node.String(),
+		Kind:  cluster.Node.String(),
+		State: cluster.Work.String(),
+	}
+
+	if err := reg.Register(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := reg.Watch(ctx, cluster.Node.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	services, err := watcher.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].State != cluster.Work.String() {
+		t.Fatalf("unexpected services: %v", services)
+	}
+
+	ins.State = cluster.Busy.String()
+
+	if err = reg.Register(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].State != cluster.Busy.String() {
+		t.Fatalf("unexpected services: %v", services)
+	}
+
+	if err = reg.Deregister(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 0 {
+		t.Fatalf("unexpected services: %v", services)
+	}
+}
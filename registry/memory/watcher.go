@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/dobyte/due/v2/registry"
+)
+
+type watcher struct {
+	state       atomic.Bool
+	ctx         context.Context
+	cancel      context.CancelFunc
+	registry    *Registry
+	serviceName string
+	chWatch     chan []*registry.ServiceInstance
+}
+
+func newWatcher(r *Registry, serviceName string) *watcher {
+	w := &watcher{}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.registry = r
+	w.serviceName = serviceName
+	w.chWatch = make(chan []*registry.ServiceInstance, 1)
+
+	return w
+}
+
+// 通知服务实例变化，未被读取的旧服务实例列表将被最新的列表替换
+func (w *watcher) notify(services []*registry.ServiceInstance) {
+	for {
+		select {
+		case w.chWatch <- services:
+			return
+		default:
+			select {
+			case <-w.chWatch:
+			default:
+			}
+		}
+	}
+}
+
+// Next 返回服务实例列表
+func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
+	if w.state.CompareAndSwap(false, true) {
+		return w.registry.Services(w.ctx, w.serviceName)
+	}
+
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case services := <-w.chWatch:
+		return services, nil
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	w.registry.recycle(w)
+
+	return nil
+}
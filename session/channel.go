@@ -0,0 +1,140 @@
+package session
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dobyte/due/v2/network"
+)
+
+// 频道采用以"."分隔的层级命名，如world.zone1.chat
+// 订阅时可使用通配符：
+// "*" 匹配一个层级，如world.*.chat可匹配world.zone1.chat
+// "#" 匹配零个或多个层级，如world.#可匹配world、world.zone1、world.zone1.chat
+const (
+	channelSeparator      = "."
+	singleLevelWildcard   = "*"
+	multipleLevelWildcard = "#"
+)
+
+// StatChannel 统计频道的订阅者数量
+// 订阅者包含订阅了该频道以及订阅了匹配该频道的通配符频道的连接
+func (s *Session) StatChannel(channel string) int64 {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	var n int64
+
+	s.visitSubscribers(channel, func(network.Conn) { n++ })
+
+	return n
+}
+
+// Channels 获取会话订阅的所有频道
+func (s *Session) Channels(kind Kind, target int64) ([]string, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	conn, err := s.conn(kind, target)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]string, 0)
+
+	conn.Attr().Visit(func(key, _ any) bool {
+		if channel, ok := key.(string); ok {
+			if _, ok = s.channels[channel]; ok {
+				channels = append(channels, channel)
+			}
+		}
+
+		return true
+	})
+
+	sort.Strings(channels)
+
+	return channels, nil
+}
+
+// 访问频道的所有订阅者，每个订阅者仅访问一次
+func (s *Session) visitSubscribers(channel string, fn func(conn network.Conn)) {
+	if len(s.patterns) == 0 {
+		for conn := range s.channels[channel] {
+			fn(conn)
+		}
+
+		return
+	}
+
+	var (
+		levels  = splitChannel(channel)
+		visited = make(map[network.Conn]struct{})
+	)
+
+	visit := func(conns map[network.Conn]struct{}) {
+		for conn := range conns {
+			if _, ok := visited[conn]; ok {
+				continue
+			}
+
+			visited[conn] = struct{}{}
+
+			fn(conn)
+		}
+	}
+
+	visit(s.channels[channel])
+
+	for pattern, segments := range s.patterns {
+		if pattern != channel && matchChannel(segments, levels) {
+			visit(s.channels[pattern])
+		}
+	}
+}
+
+// 检测频道是否包含通配符
+func isPattern(channel string) bool {
+	for _, segment := range splitChannel(channel) {
+		if segment == singleLevelWildcard || segment == multipleLevelWildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 拆分频道层级
+func splitChannel(channel string) []string {
+	return strings.Split(channel, channelSeparator)
+}
+
+// 检测通配符频道是否匹配频道
+func matchChannel(pattern, levels []string) bool {
+	for i, segment := range pattern {
+		switch segment {
+		case multipleLevelWildcard:
+			if i == len(pattern)-1 {
+				return true
+			}
+
+			for j := i; j <= len(levels); j++ {
+				if matchChannel(pattern[i+1:], levels[j:]) {
+					return true
+				}
+			}
+
+			return false
+		case singleLevelWildcard:
+			if i >= len(levels) {
+				return false
+			}
+		default:
+			if i >= len(levels) || segment != levels[i] {
+				return false
+			}
+		}
+	}
+
+	return len(pattern) == len(levels)
+}
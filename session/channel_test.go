@@ -0,0 +1,31 @@
+package session
+
+import "testing"
+
+func TestMatchChannel(t *testing.T) {
+	cases := []struct {
+		pattern string
+		channel string
+		matched bool
+	}{
+		{"world.*.chat", "world.zone1.chat", true},
+		{"world.*.chat", "world.zone1.team.chat", false},
+		{"world.*", "world", false},
+		{"world.#", "world", true},
+		{"world.#", "world.zone1.chat", true},
+		{"#.chat", "world.zone1.chat", true},
+		{"#.chat", "chat", true},
+		{"world.#.chat", "world.chat", true},
+		{"world.#.chat", "world.zone1.team.chat", true},
+		{"world.#.chat", "world.zone1.trade", false},
+		{"#", "world.zone1.chat", true},
+		{"*.*", "world.zone1", true},
+		{"*.*", "world.zone1.chat", false},
+	}
+
+	for _, c := range cases {
+		if matched := matchChannel(splitChannel(c.pattern), splitChannel(c.channel)); matched != c.matched {
+			t.Errorf("pattern: %s channel: %s want: %v got: %v", c.pattern, c.channel, c.matched, matched)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package session
+
+import "github.com/dobyte/due/v2/metrics"
+
+var (
+	connGauge      = metrics.NewGauge("due_session_connections", "Current number of connections in the session.")
+	userGauge      = metrics.NewGauge("due_session_users", "Current number of bound users in the session.")
+	pushedMessages = metrics.NewCounter("due_session_pushed_messages_total", "Total number of messages successfully written to connection queues.", "op")
+	pushFailures   = metrics.NewCounter("due_session_push_failures_total", "Total number of messages that failed to be written to connection queues.", "op")
+)
+
+// 统计推送结果，推送成功时返回true
+func observePush(op string, err error) bool {
+	if err != nil {
+		pushFailures.Add(1, op)
+		return false
+	}
+
+	pushedMessages.Add(1, op)
+
+	return true
+}
+
+// 统计会话规模变化，conns、users为变化前的连接数与用户数，需在持有写锁时调用
+func (s *Session) observeSize(conns, users int) {
+	connGauge.Add(float64(len(s.conns) - conns))
+	userGauge.Add(float64(len(s.users) - users))
+}
@@ -31,6 +31,7 @@ type Session struct {
 	conns    map[int64]network.Conn               // 连接会话（连接ID -> network.Conn）
 	users    map[int64]network.Conn               // 用户会话（用户ID -> network.Conn）
 	channels map[string]map[network.Conn]struct{} // 会话频道（频道名 -> [network.Conn --> none]）
+	patterns map[string][]string                  // 通配符频道（频道名 -> 频道层级）
 }
 
 func NewSession() *Session {
@@ -38,6 +39,7 @@ func NewSession() *Session {
 		conns:    make(map[int64]network.Conn),
 		users:    make(map[int64]network.Conn),
 		channels: make(map[string]map[network.Conn]struct{}),
+		patterns: make(map[string][]string),
 	}
 }
 
@@ -45,6 +47,7 @@ func NewSession() *Session {
 func (s *Session) AddConn(conn network.Conn) {
 	s.rw.Lock()
 	defer s.rw.Unlock()
+	defer s.observeSize(len(s.conns), len(s.users))
 
 	cid, uid := conn.ID(), conn.UID()
 
@@ -59,6 +62,7 @@ func (s *Session) AddConn(conn network.Conn) {
 func (s *Session) RemConn(conn network.Conn) {
 	s.rw.Lock()
 	defer s.rw.Unlock()
+	defer s.observeSize(len(s.conns), len(s.users))
 
 	cid, uid := conn.ID(), conn.UID()
 
@@ -96,6 +100,7 @@ func (s *Session) Has(kind Kind, target int64) (ok bool, err error) {
 func (s *Session) Bind(cid, uid int64) error {
 	s.rw.Lock()
 	defer s.rw.Unlock()
+	defer s.observeSize(len(s.conns), len(s.users))
 
 	conn, err := s.conn(Conn, cid)
 	if err != nil {
@@ -123,6 +128,7 @@ func (s *Session) Bind(cid, uid int64) error {
 func (s *Session) Unbind(uid int64) (int64, error) {
 	s.rw.Lock()
 	defer s.rw.Unlock()
+	defer s.observeSize(len(s.conns), len(s.users))
 
 	conn, err := s.conn(User, uid)
 	if err != nil {
@@ -187,6 +193,19 @@ func (s *Session) RemoteAddr(kind Kind, target int64) (net.Addr, error) {
 	return conn.RemoteAddr()
 }
 
+// QueueDepth 获取写入队列中待发送的消息数
+func (s *Session) QueueDepth(kind Kind, target int64) (int, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	conn, err := s.conn(kind, target)
+	if err != nil {
+		return 0, err
+	}
+
+	return conn.QueueDepth(), nil
+}
+
 // Close 关闭会话
 func (s *Session) Close(kind Kind, target int64, force ...bool) error {
 	s.rw.RLock()
@@ -210,7 +229,10 @@ func (s *Session) Send(kind Kind, target int64, message []byte) error {
 		return err
 	}
 
-	return conn.Send(message)
+	err = conn.Send(message)
+	observePush("send", err)
+
+	return err
 }
 
 // Push 推送消息（异步）
@@ -223,7 +245,10 @@ func (s *Session) Push(kind Kind, target int64, message []byte) error {
 		return err
 	}
 
-	return conn.Push(message)
+	err = conn.Push(message)
+	observePush("push", err)
+
+	return err
 }
 
 // Multicast 推送组播消息（异步）
@@ -251,7 +276,7 @@ func (s *Session) Multicast(kind Kind, targets []int64, message []byte) (n int64
 		if !ok {
 			continue
 		}
-		if conn.Push(message) == nil {
+		if observePush("multicast", conn.Push(message)) {
 			n++
 		}
 	}
@@ -276,7 +301,7 @@ func (s *Session) Broadcast(kind Kind, message []byte) (n int64, err error) {
 	}
 
 	for _, conn := range conns {
-		if conn.Push(message) == nil {
+		if observePush("broadcast", conn.Push(message)) {
 			n++
 		}
 	}
@@ -285,25 +310,22 @@ func (s *Session) Broadcast(kind Kind, message []byte) (n int64, err error) {
 }
 
 // Publish 发布频道消息（异步）
+// 消息将推送给订阅了该频道以及订阅了匹配该频道的通配符频道的连接，每个连接仅推送一次
 func (s *Session) Publish(channel string, message []byte) (n int64) {
 	s.rw.RLock()
 	defer s.rw.RUnlock()
 
-	channels, ok := s.channels[channel]
-	if !ok {
-		return
-	}
-
-	for conn := range channels {
-		if conn.Push(message) == nil {
+	s.visitSubscribers(channel, func(conn network.Conn) {
+		if observePush("publish", conn.Push(message)) {
 			n++
 		}
-	}
+	})
 
 	return
 }
 
 // Subscribe 订阅频道
+// 频道支持以"."分隔的层级命名，订阅时可使用"*"匹配一个层级，使用"#"匹配零个或多个层级
 func (s *Session) Subscribe(kind Kind, targets []int64, channel string) (err error) {
 	if len(targets) == 0 {
 		return
@@ -337,6 +359,10 @@ func (s *Session) Subscribe(kind Kind, targets []int64, channel string) (err err
 			channels = make(map[network.Conn]struct{}, len(targets))
 			channels[conn] = struct{}{}
 			s.channels[channel] = channels
+
+			if isPattern(channel) {
+				s.patterns[channel] = splitChannel(channel)
+			}
 		}
 	}
 
@@ -381,6 +407,7 @@ func (s *Session) doUnsubscribe(channel string, conn network.Conn) {
 
 		if len(channels) == 0 {
 			delete(s.channels, channel)
+			delete(s.patterns, channel)
 		}
 	}
 }
@@ -0,0 +1,231 @@
+package trace
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dobyte/due/v2/log"
+)
+
+const (
+	defaultQueueSize = 4096 // 待导出跨度的缓冲队列大小
+	defaultBatchSize = 256  // 单次批量导出的最大跨度数
+)
+
+var globalExporter atomic.Value
+
+func init() {
+	SetExporter(nil)
+}
+
+// SpanData 已结束的跨度数据
+type SpanData struct {
+	TraceID      string         `json:"traceId"`
+	SpanID       string         `json:"spanId"`
+	ParentSpanID string         `json:"parentSpanId,omitempty"`
+	Name         string         `json:"name"`
+	StartTime    time.Time      `json:"startTime"`
+	EndTime      time.Time      `json:"endTime"`
+	Duration     time.Duration  `json:"duration"`
+	Attributes   map[string]any `json:"attributes,omitempty"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// Exporter 跨度导出器
+type Exporter interface {
+	// Export 导出跨度
+	Export(span *SpanData) error
+}
+
+// BatchExporter 支持批量导出的跨度导出器
+// 导出器实现此接口时，后台导出协程将以批量的方式导出跨度
+type BatchExporter interface {
+	Exporter
+	// ExportBatch 批量导出跨度
+	ExportBatch(spans []*SpanData) error
+}
+
+type exporterHolder struct {
+	exporter Exporter
+	batcher  *batcher
+}
+
+// SetExporter 设置全局跨度导出器，为nil时不记录跨度，仅传递链路上下文
+// 跨度结束后将写入缓冲队列，由后台协程批量导出；替换导出器时会先导出原导出器队列中剩余的跨度
+func SetExporter(exporter Exporter) {
+	holder := exporterHolder{exporter: exporter}
+
+	if exporter != nil {
+		holder.batcher = newBatcher(exporter)
+	}
+
+	if old, ok := globalExporter.Swap(holder).(exporterHolder); ok && old.batcher != nil {
+		old.batcher.close()
+	}
+}
+
+// GetExporter 获取全局跨度导出器
+func GetExporter() Exporter {
+	return globalExporter.Load().(exporterHolder).exporter
+}
+
+// Flush 等待缓冲队列中已结束的跨度全部导出
+func Flush() {
+	if b := globalExporter.Load().(exporterHolder).batcher; b != nil {
+		b.flush()
+	}
+}
+
+// 导出跨度，缓冲队列已满时丢弃跨度，避免阻塞业务协程
+func export(span *SpanData) {
+	b := globalExporter.Load().(exporterHolder).batcher
+	if b == nil {
+		return
+	}
+
+	select {
+	case b.spans <- span:
+	default:
+		log.Debugf("span queue is full, drop span: %s", span.Name)
+	}
+}
+
+type batcher struct {
+	exporter Exporter
+	spans    chan *SpanData
+	flushes  chan chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newBatcher(exporter Exporter) *batcher {
+	b := &batcher{
+		exporter: exporter,
+		spans:    make(chan *SpanData, defaultQueueSize),
+		flushes:  make(chan chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go b.run()
+
+	return b
+}
+
+// 运行导出协程
+func (b *batcher) run() {
+	batch := make([]*SpanData, 0, defaultBatchSize)
+
+	for {
+		select {
+		case span := <-b.spans:
+			b.export(b.collect(append(batch[:0], span)))
+		case done := <-b.flushes:
+			b.drain(batch)
+			close(done)
+		case <-b.stop:
+			b.drain(batch)
+			close(b.done)
+			return
+		}
+	}
+}
+
+// 从队列中收集跨度，直至队列为空或达到单批次上限
+func (b *batcher) collect(batch []*SpanData) []*SpanData {
+	for len(batch) < defaultBatchSize {
+		select {
+		case span := <-b.spans:
+			batch = append(batch, span)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// 导出队列中剩余的所有跨度
+func (b *batcher) drain(batch []*SpanData) {
+	for {
+		if batch = b.collect(batch[:0]); len(batch) == 0 {
+			return
+		}
+
+		b.export(batch)
+	}
+}
+
+// 导出一批跨度
+func (b *batcher) export(batch []*SpanData) {
+	if exporter, ok := b.exporter.(BatchExporter); ok {
+		if err := exporter.ExportBatch(batch); err != nil {
+			log.Warnf("export spans failed: %v", err)
+		}
+		return
+	}
+
+	for _, span := range batch {
+		if err := b.exporter.Export(span); err != nil {
+			log.Warnf("export span failed: %v", err)
+		}
+	}
+}
+
+// 等待队列中的跨度导出完成
+func (b *batcher) flush() {
+	done := make(chan struct{})
+
+	select {
+	case b.flushes <- done:
+		<-done
+	case <-b.done:
+	}
+}
+
+// 关闭导出协程，关闭前导出队列中剩余的跨度
+func (b *batcher) close() {
+	close(b.stop)
+	<-b.done
+}
+
+type jsonExporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONExporter 创建JSON导出器，每个跨度以单行JSON写入w
+func NewJSONExporter(w io.Writer) Exporter {
+	return &jsonExporter{encoder: json.NewEncoder(w)}
+}
+
+// NewStdoutExporter 创建标准输出导出器
+func NewStdoutExporter() Exporter {
+	return NewJSONExporter(os.Stdout)
+}
+
+// Export 导出跨度
+func (e *jsonExporter) Export(span *SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.encoder.Encode(span)
+}
+
+// ExportBatch 批量导出跨度
+func (e *jsonExporter) ExportBatch(spans []*SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, span := range spans {
+		if err := e.encoder.Encode(span); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
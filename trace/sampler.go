@@ -0,0 +1,61 @@
+package trace
+
+import (
+	"encoding/binary"
+	"math"
+	"sync/atomic"
+)
+
+var globalSampler atomic.Value
+
+func init() {
+	SetSampler(nil)
+}
+
+// Sampler 采样器，用于决定新创建的链路是否被采样；子跨度沿用父跨度的采样结果
+type Sampler func(traceID TraceID, name string) bool
+
+type samplerHolder struct {
+	sampler Sampler
+}
+
+// SetSampler 设置全局采样器，为nil时对所有链路进行采样
+func SetSampler(sampler Sampler) {
+	globalSampler.Store(samplerHolder{sampler: sampler})
+}
+
+// AlwaysSample 对所有链路进行采样
+func AlwaysSample() Sampler {
+	return func(TraceID, string) bool { return true }
+}
+
+// NeverSample 不对任何链路进行采样
+func NeverSample() Sampler {
+	return func(TraceID, string) bool { return false }
+}
+
+// RatioSampler 按比例对链路进行采样，ratio取值范围为[0, 1]
+// 采样结果仅取决于链路ID，同一链路在不同进程中的采样结果一致
+func RatioSampler(ratio float64) Sampler {
+	switch {
+	case ratio >= 1:
+		return AlwaysSample()
+	case ratio <= 0:
+		return NeverSample()
+	}
+
+	bound := uint64(ratio * math.MaxUint64)
+
+	return func(traceID TraceID, _ string) bool {
+		return binary.BigEndian.Uint64(traceID[8:]) < bound
+	}
+}
+
+// 检测新创建的链路是否被采样
+func sample(traceID TraceID, name string) bool {
+	if sampler := globalSampler.Load().(samplerHolder).sampler; sampler != nil {
+		return sampler(traceID, name)
+	}
+
+	return true
+}
@@ -0,0 +1,328 @@
+package trace
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	Header          = "traceparent" // 链路上下文在元数据中的键，取值遵循W3C Trace Context规范
+	SpanContextSize = 25            // 链路上下文的二进制长度，traceID(16) + spanID(8) + flags(1)
+)
+
+const sampledFlag = 0x01
+
+type spanKey struct{}
+
+// TraceID 链路ID
+type TraceID [16]byte
+
+// IsValid 检测链路ID是否有效
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// String 获取链路ID的十六进制表示
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID 跨度ID
+type SpanID [8]byte
+
+// IsValid 检测跨度ID是否有效
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// String 获取跨度ID的十六进制表示
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext 链路上下文，跨进程传递时仅传递链路上下文
+type SpanContext struct {
+	TraceID TraceID // 链路ID
+	SpanID  SpanID  // 跨度ID
+	Sampled bool    // 是否采样
+}
+
+// IsValid 检测链路上下文是否有效
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// String 获取W3C traceparent格式的链路上下文
+func (sc SpanContext) String() string {
+	if !sc.IsValid() {
+		return ""
+	}
+
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// Bytes 获取链路上下文的二进制表示，链路上下文无效时返回全零字节
+func (sc SpanContext) Bytes() []byte {
+	b := make([]byte, SpanContextSize)
+
+	if !sc.IsValid() {
+		return b
+	}
+
+	copy(b[:16], sc.TraceID[:])
+	copy(b[16:24], sc.SpanID[:])
+
+	if sc.Sampled {
+		b[24] = sampledFlag
+	}
+
+	return b
+}
+
+// ParseSpanContext 解析W3C traceparent格式的链路上下文
+func ParseSpanContext(s string) (sc SpanContext, ok bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return
+	}
+
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return
+	}
+
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, false
+	}
+
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, false
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return SpanContext{}, false
+	}
+
+	sc.Sampled = flags[0]&sampledFlag != 0
+
+	return sc, sc.IsValid()
+}
+
+// SpanContextFromBytes 从二进制数据中解析链路上下文
+func SpanContextFromBytes(b []byte) (sc SpanContext, ok bool) {
+	if len(b) != SpanContextSize {
+		return
+	}
+
+	copy(sc.TraceID[:], b[:16])
+	copy(sc.SpanID[:], b[16:24])
+	sc.Sampled = b[24]&sampledFlag != 0
+
+	return sc, sc.IsValid()
+}
+
+// Span 跨度
+// 未设置导出器或未被采样的跨度不会被记录，但仍会向下游传递链路上下文
+type Span struct {
+	mu         sync.Mutex
+	sc         SpanContext
+	parent     SpanID
+	name       string
+	start      time.Time
+	attributes map[string]any
+	err        string
+	recording  bool
+	finished   bool
+}
+
+// Context 获取跨度的链路上下文
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+
+	return s.sc
+}
+
+// IsRecording 检测跨度是否被记录
+func (s *Span) IsRecording() bool {
+	return s != nil && s.recording
+}
+
+// SetAttribute 设置跨度属性
+func (s *Span) SetAttribute(key string, value any) {
+	if !s.IsRecording() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished {
+		return
+	}
+
+	if s.attributes == nil {
+		s.attributes = make(map[string]any)
+	}
+
+	s.attributes[key] = value
+}
+
+// SetError 设置跨度错误，err为nil时忽略
+func (s *Span) SetError(err error) {
+	if err == nil || !s.IsRecording() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.finished {
+		s.err = err.Error()
+	}
+}
+
+// Finish 结束跨度并写入导出队列，重复调用时仅首次生效
+func (s *Span) Finish() {
+	if !s.IsRecording() {
+		return
+	}
+
+	s.mu.Lock()
+	if s.finished {
+		s.mu.Unlock()
+		return
+	}
+	s.finished = true
+	end := time.Now()
+	data := &SpanData{
+		TraceID:    s.sc.TraceID.String(),
+		SpanID:     s.sc.SpanID.String(),
+		Name:       s.name,
+		StartTime:  s.start,
+		EndTime:    end,
+		Duration:   end.Sub(s.start),
+		Attributes: s.attributes,
+		Error:      s.err,
+	}
+	if s.parent.IsValid() {
+		data.ParentSpanID = s.parent.String()
+	}
+	s.mu.Unlock()
+
+	export(data)
+}
+
+// Start 开启跨度，上下文中存在链路上下文时创建子跨度，否则创建新的链路并由采样器决定是否采样
+// 未设置导出器且上下文中不存在链路上下文时返回原上下文及空跨度，空跨度的所有方法均可安全调用
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	parent := SpanContextFromContext(ctx)
+	exporting := GetExporter() != nil
+
+	if !parent.IsValid() && !exporting {
+		return ctx, nil
+	}
+
+	span := &Span{name: name, start: time.Now()}
+
+	if parent.IsValid() {
+		span.sc = SpanContext{TraceID: parent.TraceID, SpanID: newSpanID(), Sampled: parent.Sampled}
+		span.parent = parent.SpanID
+	} else {
+		traceID := newTraceID()
+		span.sc = SpanContext{TraceID: traceID, SpanID: newSpanID(), Sampled: sample(traceID, name)}
+	}
+
+	span.recording = exporting && span.sc.Sampled
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SpanFromContext 获取上下文中的跨度，不存在时返回nil
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+
+	span, _ := ctx.Value(spanKey{}).(*Span)
+
+	return span
+}
+
+// SpanContextFromContext 获取上下文中的链路上下文
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	return SpanFromContext(ctx).Context()
+}
+
+// ContextWithSpanContext 将远端传递的链路上下文写入上下文，后续开启的跨度将作为其子跨度
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+
+	return context.WithValue(ctx, spanKey{}, &Span{sc: sc})
+}
+
+// Detach 创建仅保留链路上下文的新上下文，用于在原上下文结束后继续处理的场景
+func Detach(ctx context.Context) context.Context {
+	return ContextWithSpanContext(context.Background(), SpanContextFromContext(ctx))
+}
+
+// Inject 获取上下文中W3C traceparent格式的链路上下文，不存在时返回空字符串
+func Inject(ctx context.Context) string {
+	return SpanContextFromContext(ctx).String()
+}
+
+// Extract 从W3C traceparent格式的链路上下文中提取链路信息并写入上下文
+func Extract(ctx context.Context, traceparent string) context.Context {
+	if sc, ok := ParseSpanContext(traceparent); ok {
+		return ContextWithSpanContext(ctx, sc)
+	}
+
+	return ctx
+}
+
+// ValueSetter 可直接写入值的上下文，如rpcx的share.Context
+type ValueSetter interface {
+	SetValue(key, val any)
+}
+
+// SetSpan 将跨度写入无法替换的上下文中
+func SetSpan(setter ValueSetter, span *Span) {
+	if span != nil {
+		setter.SetValue(spanKey{}, span)
+	}
+}
+
+func newTraceID() (id TraceID) {
+	if _, err := crand.Read(id[:]); err != nil {
+		binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	}
+
+	return
+}
+
+func newSpanID() (id SpanID) {
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+
+	return
+}
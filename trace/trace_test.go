@@ -0,0 +1,154 @@
+package trace_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/trace"
+)
+
+func TestStart(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	trace.SetExporter(trace.NewJSONExporter(buf))
+	defer trace.SetExporter(nil)
+
+	ctx, root := trace.Start(context.Background(), "root")
+	root.SetAttribute("uid", 1)
+
+	// 模拟跨进程传递
+	remote, ok := trace.SpanContextFromBytes(trace.SpanContextFromContext(ctx).Bytes())
+	if !ok {
+		t.Fatal("decode span context failed")
+	}
+
+	_, child := trace.Start(trace.ContextWithSpanContext(context.Background(), remote), "child")
+	child.SetError(errors.New("failed"))
+	child.Finish()
+	root.Finish()
+	root.Finish()
+	trace.Flush()
+
+	spans := make([]trace.SpanData, 0, 2)
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		span := trace.SpanData{}
+		if err := json.Unmarshal(scanner.Bytes(), &span); err != nil {
+			t.Fatal(err)
+		}
+		spans = append(spans, span)
+	}
+
+	if len(spans) != 2 {
+		t.Fatalf("span count mismatch, want: 2 got: %d", len(spans))
+	}
+
+	if spans[0].Name != "child" || spans[0].Error != "failed" || spans[0].ParentSpanID != spans[1].SpanID {
+		t.Fatalf("unexpected child span: %+v", spans[0])
+	}
+
+	if spans[0].TraceID != spans[1].TraceID || spans[1].ParentSpanID != "" {
+		t.Fatalf("unexpected root span: %+v", spans[1])
+	}
+
+	if spans[1].Attributes["uid"] != float64(1) {
+		t.Fatalf("unexpected root attributes: %v", spans[1].Attributes)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	trace.SetExporter(trace.NewJSONExporter(buf))
+	defer trace.SetExporter(nil)
+
+	trace.SetSampler(trace.NeverSample())
+	defer trace.SetSampler(nil)
+
+	ctx, root := trace.Start(context.Background(), "root")
+	_, child := trace.Start(ctx, "child")
+	child.Finish()
+	root.Finish()
+	trace.Flush()
+
+	if root.IsRecording() || child.IsRecording() || buf.Len() != 0 {
+		t.Fatalf("unsampled spans should not be exported: %s", buf.String())
+	}
+
+	// 未采样的链路仍向下游传递链路上下文
+	if sc := trace.SpanContextFromContext(ctx); !sc.IsValid() || sc.Sampled {
+		t.Fatalf("unexpected span context: %+v", sc)
+	}
+
+	if sampler := trace.RatioSampler(0.5); sampler(trace.TraceID{8: 0x10}, "") == sampler(trace.TraceID{8: 0xf0}, "") {
+		t.Fatal("ratio sampler should sample by trace id")
+	}
+}
+
+func TestExportAsync(t *testing.T) {
+	exporter := &blockingExporter{release: make(chan struct{}), exported: make(chan string, 1)}
+
+	trace.SetExporter(exporter)
+	defer trace.SetExporter(nil)
+
+	_, span := trace.Start(context.Background(), "async")
+
+	finished := make(chan struct{})
+	go func() {
+		span.Finish()
+		close(finished)
+	}()
+
+	// 导出器阻塞时结束跨度不应被阻塞
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("span finish is blocked by exporter")
+	}
+
+	close(exporter.release)
+
+	if name := <-exporter.exported; name != "async" {
+		t.Fatalf("exported span mismatch, got: %s", name)
+	}
+}
+
+type blockingExporter struct {
+	release  chan struct{}
+	exported chan string
+}
+
+func (e *blockingExporter) Export(span *trace.SpanData) error {
+	<-e.release
+	e.exported <- span.Name
+	return nil
+}
+
+func TestInject(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	ctx := trace.Extract(context.Background(), traceparent)
+
+	if s := trace.Inject(ctx); s != traceparent {
+		t.Fatalf("traceparent mismatch, want: %s got: %s", traceparent, s)
+	}
+
+	// 未设置导出器时仍向下游传递链路上下文
+	ctx, span := trace.Start(ctx, "child")
+	span.SetAttribute("ignored", true)
+	span.Finish()
+
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.IsRecording() {
+		t.Fatalf("unexpected span context: %s", sc)
+	}
+
+	if ctx, span = trace.Start(context.Background(), "root"); span != nil || trace.Inject(ctx) != "" {
+		t.Fatal("span should not be created without exporter and parent")
+	}
+}
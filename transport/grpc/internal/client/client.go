@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 
+	"github.com/dobyte/due/v2/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type Client struct {
@@ -15,7 +17,8 @@ func NewClient(cc *grpc.ClientConn) *Client {
 }
 
 // Call 调用服务方法
-func (c *Client) Call(ctx context.Context, service, method string, args any, reply any, opts ...any) error {
+// ctx中存在链路上下文时，链路上下文将通过元数据传递给服务端
+func (c *Client) Call(ctx context.Context, service, method string, args any, reply any, opts ...any) (err error) {
 	path := ""
 
 	if service != "" {
@@ -33,6 +36,16 @@ func (c *Client) Call(ctx context.Context, service, method string, args any, rep
 		}
 	}
 
+	ctx, span := trace.Start(ctx, path)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
+	if traceparent := trace.Inject(ctx); traceparent != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, trace.Header, traceparent)
+	}
+
 	return c.cc.Invoke(ctx, path, args, reply, options...)
 }
 
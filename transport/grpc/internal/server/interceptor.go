@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"runtime"
 )
 
@@ -21,3 +23,20 @@ func recoverInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo
 
 	return handler(ctx, req)
 }
+
+// 从元数据中提取链路上下文并开启服务端跨度
+func traceInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (reply any, err error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(trace.Header); len(values) > 0 {
+			ctx = trace.Extract(ctx, values[0])
+		}
+	}
+
+	ctx, span := trace.Start(ctx, info.FullMethod)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
+	return handler(ctx, req)
+}
@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 
+	"github.com/dobyte/due/v2/trace"
 	cli "github.com/smallnest/rpcx/client"
+	"github.com/smallnest/rpcx/share"
 )
 
 type Client struct {
@@ -15,7 +17,24 @@ func NewClient(cli *cli.OneClient) *Client {
 }
 
 // Call 调用服务方法
-func (c *Client) Call(ctx context.Context, service, method string, args any, reply any, opts ...any) error {
+// ctx中存在链路上下文时，链路上下文将通过元数据传递给服务端
+func (c *Client) Call(ctx context.Context, service, method string, args any, reply any, opts ...any) (err error) {
+	ctx, span := trace.Start(ctx, service+"/"+method)
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
+	if traceparent := trace.Inject(ctx); traceparent != "" {
+		md, _ := ctx.Value(share.ReqMetaDataKey).(map[string]string)
+		metadata := make(map[string]string, len(md)+1)
+		for k, v := range md {
+			metadata[k] = v
+		}
+		metadata[trace.Header] = traceparent
+		ctx = context.WithValue(ctx, share.ReqMetaDataKey, metadata)
+	}
+
 	return c.cli.Call(ctx, service, method, args, reply)
 }
 
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+
+	"github.com/dobyte/due/v2/trace"
+	"github.com/smallnest/rpcx/share"
+)
+
+// 链路追踪插件，从元数据中提取链路上下文并开启服务端跨度
+type tracePlugin struct{}
+
+// PreCall 调用服务方法前开启跨度
+func (p *tracePlugin) PreCall(ctx context.Context, serviceName, methodName string, args any) (any, error) {
+	c, ok := ctx.(*share.Context)
+	if !ok {
+		return args, nil
+	}
+
+	md, _ := ctx.Value(share.ReqMetaDataKey).(map[string]string)
+
+	_, span := trace.Start(trace.Extract(ctx, md[trace.Header]), serviceName+"/"+methodName)
+
+	trace.SetSpan(c, span)
+
+	return args, nil
+}
+
+// PostCall 调用服务方法后结束跨度
+func (p *tracePlugin) PostCall(ctx context.Context, serviceName, methodName string, args, reply any, err error) (any, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetError(err)
+	span.Finish()
+
+	return reply, err
+}
@@ -49,6 +49,7 @@ func NewServer(opts *Options) (*Server, error) {
 	s.listenAddr = listenAddr
 	s.exposeAddr = exposeAddr
 	s.server = server.NewServer(serverOpts...)
+	s.server.Plugins.Add(&tracePlugin{})
 	s.endpoint = endpoint.NewEndpoint(scheme, exposeAddr, isSecure)
 
 	return s, nil